@@ -0,0 +1,179 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CIP-25 NFT metadata is published under this transaction metadata label
+const cip25MetadataLabel = 721
+
+// Cip25Asset holds the commonly used fields of a CIP-25 NFT asset
+type Cip25Asset struct {
+	PolicyId    string
+	AssetName   string
+	Name        string
+	Image       string
+	MediaType   string
+	Description string
+}
+
+// decodeCip25Metadata parses the label 721 entry of the provided transaction
+// metadata CBOR into a list of assets. It returns nil if the metadata does
+// not contain CIP-25 NFT metadata
+func decodeCip25Metadata(metadataCbor []byte) ([]Cip25Asset, error) {
+	// CIP-25 version 2 uses byte strings for policy IDs and asset names,
+	// which we need to allow as map keys
+	decMode, err := cbor.DecOptions{
+		MapKeyByteString: cbor.MapKeyByteStringAllowed,
+	}.DecMode()
+	if err != nil {
+		return nil, err
+	}
+	metadataCbor, err = unwrapAuxiliaryData(decMode, metadataCbor)
+	if err != nil || metadataCbor == nil {
+		return nil, err
+	}
+	var metadata map[uint64]cbor.RawMessage
+	if err := decMode.Unmarshal(metadataCbor, &metadata); err != nil {
+		return nil, err
+	}
+	nftCbor, ok := metadata[cip25MetadataLabel]
+	if !ok {
+		return nil, nil
+	}
+	var policies map[interface{}]interface{}
+	if err := decMode.Unmarshal(nftCbor, &policies); err != nil {
+		return nil, err
+	}
+	var assets []Cip25Asset
+	for policyKey, policyVal := range policies {
+		policyAssets, ok := policyVal.(map[interface{}]interface{})
+		if !ok {
+			// Skip non-policy entries such as "version"
+			continue
+		}
+		for assetKey, assetVal := range policyAssets {
+			fields, ok := assetVal.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			assets = append(
+				assets,
+				Cip25Asset{
+					PolicyId:    cip25PolicyId(policyKey),
+					AssetName:   cip25String(assetKey),
+					Name:        cip25String(fields["name"]),
+					Image:       cip25String(fields["image"]),
+					MediaType:   cip25String(fields["mediaType"]),
+					Description: cip25String(fields["description"]),
+				},
+			)
+		}
+	}
+	// Sort assets for consistent output
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i].PolicyId != assets[j].PolicyId {
+			return assets[i].PolicyId < assets[j].PolicyId
+		}
+		return assets[i].AssetName < assets[j].AssetName
+	})
+	return assets, nil
+}
+
+// Tag used by the Alonzo and later form of transaction auxiliary data
+const auxiliaryDataTag = 259
+
+// unwrapAuxiliaryData returns the metadata map CBOR from transaction auxiliary
+// data, which can be a plain metadata map (Shelley), an array of metadata and
+// scripts (Allegra and Mary), or a tagged map with the metadata under key 0
+// (Alonzo and later). It returns nil if there is no metadata
+func unwrapAuxiliaryData(
+	decMode cbor.DecMode,
+	auxDataCbor []byte,
+) ([]byte, error) {
+	if len(auxDataCbor) == 0 {
+		return nil, nil
+	}
+	// Check the CBOR major type in the high bits of the first byte
+	switch auxDataCbor[0] >> 5 {
+	case 4:
+		var auxData []cbor.RawMessage
+		if err := decMode.Unmarshal(auxDataCbor, &auxData); err != nil {
+			return nil, err
+		}
+		if len(auxData) == 0 {
+			return nil, nil
+		}
+		return auxData[0], nil
+	case 6:
+		var tag cbor.RawTag
+		if err := decMode.Unmarshal(auxDataCbor, &tag); err != nil {
+			return nil, err
+		}
+		if tag.Number != auxiliaryDataTag {
+			return nil, fmt.Errorf("unexpected auxiliary data tag: %d", tag.Number)
+		}
+		var auxData map[uint64]cbor.RawMessage
+		if err := decMode.Unmarshal(tag.Content, &auxData); err != nil {
+			return nil, err
+		}
+		metadataCbor, ok := auxData[0]
+		if !ok {
+			return nil, nil
+		}
+		return metadataCbor, nil
+	}
+	return auxDataCbor, nil
+}
+
+// cip25PolicyId converts a policy ID map key, which is a hex string in
+// CIP-25 version 1 and a byte string in version 2, into a hex string
+func cip25PolicyId(val interface{}) string {
+	if b, ok := val.(cbor.ByteString); ok {
+		return hex.EncodeToString([]byte(b))
+	}
+	return cip25String(val)
+}
+
+// cip25String converts a CIP-25 metadata value into a string. Values longer
+// than 64 bytes are split into an array of strings, which we join back
+// together
+func cip25String(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case cbor.ByteString:
+		return string(v)
+	case []byte:
+		return string(v)
+	case []interface{}:
+		var parts []string
+		for _, part := range v {
+			parts = append(parts, cip25String(part))
+		}
+		return strings.Join(parts, "")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const testPolicyId = "1ec85dcee27f2d90ec1f9a1e4ce74a667dc9be8b184463223f9c9601"
+
+// The asset we expect to decode from the test metadata
+var testCip25Asset = Cip25Asset{
+	PolicyId:    testPolicyId,
+	AssetName:   "TestNFT",
+	Name:        "Test NFT",
+	Image:       "ipfs://QmTestImageHashThatIsSplitAcrossTwoStrings",
+	MediaType:   "image/png",
+	Description: "A test NFT",
+}
+
+// Fields of the test asset, with the image split into an array of strings as
+// is done for values longer than 64 bytes
+var testCip25Fields = map[string]any{
+	"name":        "Test NFT",
+	"image":       []string{"ipfs://QmTestImageHash", "ThatIsSplitAcrossTwoStrings"},
+	"mediaType":   "image/png",
+	"description": "A test NFT",
+}
+
+// Build transaction metadata containing the test asset under label 721, using
+// string keys for CIP-25 version 1 and byte string keys for version 2
+func newTestCip25Metadata(t *testing.T, version int) map[uint64]any {
+	t.Helper()
+	if version == 1 {
+		return map[uint64]any{
+			cip25MetadataLabel: map[string]any{
+				testPolicyId: map[string]any{
+					"TestNFT": testCip25Fields,
+				},
+				"version": "1.0",
+			},
+		}
+	}
+	policyId, err := hex.DecodeString(testPolicyId)
+	if err != nil {
+		t.Fatalf("unexpected error decoding policy ID: %s", err)
+	}
+	return map[uint64]any{
+		cip25MetadataLabel: map[any]any{
+			cbor.ByteString(policyId): map[any]any{
+				cbor.ByteString("TestNFT"): testCip25Fields,
+			},
+			"version": 2,
+		},
+	}
+}
+
+func TestDecodeCip25Metadata(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		version int
+		// Wraps the metadata map in the era's auxiliary data form
+		wrap func(metadata map[uint64]any) any
+	}{
+		{
+			name:    "Shelley map v1",
+			version: 1,
+			wrap:    func(metadata map[uint64]any) any { return metadata },
+		},
+		{
+			name:    "Shelley map v2",
+			version: 2,
+			wrap:    func(metadata map[uint64]any) any { return metadata },
+		},
+		{
+			name:    "Allegra array v1",
+			version: 1,
+			wrap: func(metadata map[uint64]any) any {
+				return []any{metadata, []any{}}
+			},
+		},
+		{
+			name:    "Allegra array v2",
+			version: 2,
+			wrap: func(metadata map[uint64]any) any {
+				return []any{metadata, []any{}}
+			},
+		},
+		{
+			name:    "Alonzo tag 259 v1",
+			version: 1,
+			wrap: func(metadata map[uint64]any) any {
+				return cbor.Tag{
+					Number:  auxiliaryDataTag,
+					Content: map[uint64]any{0: metadata},
+				}
+			},
+		},
+		{
+			name:    "Alonzo tag 259 v2",
+			version: 2,
+			wrap: func(metadata map[uint64]any) any {
+				return cbor.Tag{
+					Number:  auxiliaryDataTag,
+					Content: map[uint64]any{0: metadata},
+				}
+			},
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			auxDataCbor, err := cbor.Marshal(
+				testDef.wrap(newTestCip25Metadata(t, testDef.version)),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error encoding metadata: %s", err)
+			}
+			assets, err := decodeCip25Metadata(auxDataCbor)
+			if err != nil {
+				t.Fatalf("unexpected error decoding metadata: %s", err)
+			}
+			expected := []Cip25Asset{testCip25Asset}
+			if !reflect.DeepEqual(assets, expected) {
+				t.Errorf(
+					"did not get expected assets\n  got:    %#v\n  wanted: %#v",
+					assets,
+					expected,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeCip25MetadataWithoutLabel(t *testing.T) {
+	auxDataCbor, err := cbor.Marshal(
+		cbor.Tag{
+			Number: auxiliaryDataTag,
+			Content: map[uint64]any{
+				0: map[uint64]any{674: map[string]any{"msg": []string{"hi"}}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error encoding metadata: %s", err)
+	}
+	assets, err := decodeCip25Metadata(auxDataCbor)
+	if err != nil {
+		t.Fatalf("unexpected error decoding metadata: %s", err)
+	}
+	if assets != nil {
+		t.Errorf("expected no assets, got: %#v", assets)
+	}
+}
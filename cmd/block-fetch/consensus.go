@@ -0,0 +1,68 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// printConsensusInfo displays the VRF and operational certificate details
+// from the block header, for analyzing leader election
+func printConsensusInfo(block ledger.Block) {
+	info, ok := common.BlockHeaderInfo(block)
+	if !ok {
+		fmt.Println("Consensus: not available for this era")
+		return
+	}
+	fmt.Println("Consensus:")
+	fmt.Printf("  VRF key: %x\n", info.VrfKey)
+	fmt.Printf(
+		"  Protocol version: %d.%d\n",
+		info.ProtoMajorVersion,
+		info.ProtoMinorVersion,
+	)
+	fmt.Printf(
+		"  Op cert: sequence = %d, KES period = %d\n",
+		info.OpCertSequenceNumber,
+		info.OpCertKesPeriod,
+	)
+	if info.NonceVrf != nil {
+		// TPraos uses separate VRF results for leader election and the nonce
+		fmt.Printf("  Leader VRF output: %x\n", info.LeaderVrf.Output)
+		fmt.Printf("  Leader VRF proof: %x\n", info.LeaderVrf.Proof)
+		fmt.Printf("  Nonce VRF output: %x\n", info.NonceVrf.Output)
+		fmt.Printf("  Nonce VRF proof: %x\n", info.NonceVrf.Proof)
+		return
+	}
+	// Praos derives the leader and nonce values from a single VRF output,
+	// by hashing it with a "L" or "N" prefix. The nonce is then hashed again
+	fmt.Printf("  VRF output: %x\n", info.LeaderVrf.Output)
+	fmt.Printf("  VRF proof: %x\n", info.LeaderVrf.Proof)
+	fmt.Printf(
+		"  Leader value: %s\n",
+		lcommon.Blake2b256Hash(append([]byte("L"), info.LeaderVrf.Output...)),
+	)
+	nonceHash := lcommon.Blake2b256Hash(
+		append([]byte("N"), info.LeaderVrf.Output...),
+	)
+	fmt.Printf(
+		"  Nonce value: %s\n",
+		lcommon.Blake2b256Hash(nonceHash.Bytes()),
+	)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+// fetchBlockWithRetry fetches the block at the provided point, re-dialing
+// and retrying with an exponential backoff when the connection fails or
+// times out. Protocol errors and replies, such as a refused handshake or the
+// block not being found, are returned immediately since retrying won't help
+func fetchBlockWithRetry(cfg Config, point ocommon.Point) (ledger.Block, error) {
+	return retryFetch(cfg, func() (ledger.Block, error) {
+		return fetchBlock(cfg, point)
+	})
+}
+
+// retryFetch calls the fetch function until it succeeds, fails with an error
+// which isn't worth retrying, or runs out of retries
+func retryFetch(
+	cfg Config,
+	fetch func() (ledger.Block, error),
+) (ledger.Block, error) {
+	delay := cfg.RetryDelay
+	for attempt := uint(0); ; attempt++ {
+		block, err := fetch()
+		if err == nil {
+			return block, nil
+		}
+		var connErr *common.ConnectionError
+		retryable := errors.As(err, &connErr) || common.IsTimeout(err)
+		if !retryable || attempt >= cfg.Retries {
+			return nil, err
+		}
+		common.Warnf(
+			"failed to fetch block (attempt %d of %d), retrying in %s: %s",
+			attempt+1,
+			cfg.Retries+1,
+			delay,
+			err,
+		)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// The result of fetching a block
+type fetchResult struct {
+	block ledger.Block
+	err   error
+}
+
+// fetchBlock connects to the Node and fetches the block at the provided point
+// via NtN BlockFetch. A new connection is used each time, so that a retry
+// after a relay disconnect starts fresh
+func fetchBlock(cfg Config, point ocommon.Point) (ledger.Block, error) {
+	// Create error channel. Async errors are returned rather than exiting, so
+	// that they can be retried
+	errorChan := make(chan error, 10)
+	// Configure Ouroboros
+	o, err := ouroboros.NewConnection(
+		ouroboros.WithNetworkMagic(cfg.NetworkMagic),
+		ouroboros.WithErrorChan(errorChan),
+		ouroboros.WithLogger(common.Logger()),
+		ouroboros.WithNodeToNode(true),
+		ouroboros.WithKeepAlive(true),
+		ouroboros.WithKeepAliveConfig(
+			common.KeepAliveConfig(
+				cfg.KeepaliveInterval,
+				cfg.KeepaliveTimeout,
+			),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = o.Close()
+	}()
+	// Connect to the Node and get the requested block via NtN BlockFetch in
+	// the background, so that a relay which stalls during the handshake or
+	// the fetch can't block us past the deadline
+	resultChan := make(chan fetchResult, 1)
+	go func() {
+		// DialNode also performs the handshake, so only wrap errors from the
+		// connection itself. A refused handshake won't succeed on a retry
+		if err := common.DialNode(o, cfg.Address); err != nil {
+			resultChan <- fetchResult{err: common.WrapConnectionError(err)}
+			return
+		}
+		block, err := o.BlockFetch().Client.GetBlock(point)
+		resultChan <- fetchResult{block: block, err: err}
+	}()
+	return awaitBlock(resultChan, errorChan, cfg.Timeout)
+}
+
+// awaitBlock waits for the fetch result or an async connection error, giving
+// up after the timeout with an error which is classified as a timeout
+func awaitBlock(
+	resultChan <-chan fetchResult,
+	errorChan <-chan error,
+	timeout time.Duration,
+) (ledger.Block, error) {
+	select {
+	case result := <-resultChan:
+		if result.err != nil {
+			return nil, result.err
+		}
+		if result.block == nil {
+			return nil, errors.New("empty block! this shouldn't happen")
+		}
+		return result.block, nil
+	case err := <-errorChan:
+		return nil, common.WrapConnectionError(err)
+	case <-time.After(timeout):
+		return nil, fmt.Errorf(
+			"timed out after %s waiting for block: %w",
+			timeout,
+			context.DeadlineExceeded,
+		)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestAwaitBlockTimeout(t *testing.T) {
+	// A stub relay which never answers
+	resultChan := make(chan fetchResult)
+	errorChan := make(chan error)
+	_, err := awaitBlock(resultChan, errorChan, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got: %v", err)
+	}
+	if !common.IsTimeout(err) {
+		t.Errorf("expected the error to be classified as a timeout")
+	}
+}
+
+func TestAwaitBlockProtocolError(t *testing.T) {
+	resultChan := make(chan fetchResult)
+	errorChan := make(chan error, 1)
+	errorChan <- errors.New("protocol error: handshake refused")
+	_, err := awaitBlock(resultChan, errorChan, time.Second)
+	var connErr *common.ConnectionError
+	if errors.As(err, &connErr) {
+		t.Errorf("expected a protocol error not to be a connection error")
+	}
+}
+
+func TestRetryFetchRetriesTimeouts(t *testing.T) {
+	cfg := Config{
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+	}
+	common.SetQuiet(true)
+	defer common.SetQuiet(false)
+	attempts := 0
+	_, err := retryFetch(cfg, func() (ledger.Block, error) {
+		attempts++
+		// A stub relay which never answers
+		return awaitBlock(
+			make(chan fetchResult),
+			make(chan error),
+			time.Millisecond,
+		)
+	})
+	if !common.IsTimeout(err) {
+		t.Fatalf("expected a timeout error, got: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryFetchDoesNotRetryProtocolErrors(t *testing.T) {
+	cfg := Config{
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+	}
+	attempts := 0
+	_, err := retryFetch(cfg, func() (ledger.Block, error) {
+		attempts++
+		return nil, errors.New("protocol error: handshake refused")
+	})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
@@ -18,86 +18,128 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"text/template"
+	"time"
 
-	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	gcbor "github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/kelseyhightower/envconfig"
 )
 
 // We parse environment variables using envconfig into this struct
 type Config struct {
-	Address      string
-	Hash         string
-	Network      string
-	NetworkMagic uint32 `split_words:"true"`
-	ReturnCbor   bool   `split_words:"true"`
-	Slot         uint64
+	Address           string
+	AssetsOnly        bool `split_words:"true"`
+	CborDiag          bool `split_words:"true"`
+	Consensus         bool
+	DatumHash         []string `split_words:"true"`
+	DumpScript        bool     `split_words:"true"`
+	FlatAssets        bool     `split_words:"true"`
+	HasDatum          bool     `split_words:"true"`
+	Hash              string
+	KeepaliveInterval time.Duration `split_words:"true"`
+	KeepaliveTimeout  time.Duration `split_words:"true"`
+	Network           string
+	NetworkMagic      uint32 `split_words:"true"`
+	Nft               bool
+	Retries           uint
+	RetryDelay        time.Duration `split_words:"true"`
+	ReturnCbor        bool          `split_words:"true"`
+	Slot              uint64
+	Template          string
+	Timeout           time.Duration
 }
 
 // This code will be executed when run
 func main() {
 	// Set config defaults (first mainnet Babbage block)
 	var cfg = Config{
-		Address:      "backbone.cardano.iog.io:3001",
+		Address:      common.DefaultNodeAddress,
+		AssetsOnly:   false,
 		Hash:         "eea1247726ababb0b15ef7068b6917ceb6ebe3021c40fe44608585bba44e24b6",
-		Network:      "mainnet",
+		Network:      "",
 		NetworkMagic: 0,
+		Nft:          false,
+		Retries:      3,
+		RetryDelay:   time.Second,
 		ReturnCbor:   false,
 		Slot:         72316896,
+		Template:     "",
+		Timeout:      30 * time.Second,
 	}
-	// Parse environment variables
+	// Parse command line flags
+	common.SetUsageEpilogue(templateUsage)
+	common.ParseFlags()
+	// Parse environment variables. The shared CARDANO_NODE_ADDRESS takes
+	// precedence over BLOCK_FETCH_ADDRESS, while a network name or magic given
+	// for this tool takes precedence over CARDANO_NODE_MAGIC
 	if err := envconfig.Process("block_fetch", &cfg); err != nil {
-		panic(err)
+		common.Exit(common.ExitUsage, err)
 	}
-	// Configure NetworkMagic
-	if cfg.NetworkMagic == 0 {
-		network, ok := ouroboros.NetworkByName(cfg.Network)
-		if !ok {
-			fmt.Printf("invalid network specified: %v\n", cfg.Network)
-			os.Exit(1)
-		}
-		cfg.NetworkMagic = network.NetworkMagic
-	}
-	// Create error channel
-	errorChan := make(chan error)
-	// start error handler
-	go func() {
-		for {
-			err := <-errorChan
-			panic(err)
+	nodeCfg, err := common.LoadNodeConfig()
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if nodeCfg.Address != "" {
+		common.WarnIgnoredEnv("CARDANO_NODE_ADDRESS", "BLOCK_FETCH_ADDRESS")
+		cfg.Address = nodeCfg.Address
+	}
+	// Parse output template, if provided
+	var tmpl *template.Template
+	if cfg.Template != "" {
+		var err error
+		tmpl, err = template.New("block").Parse(cfg.Template)
+		if err != nil {
+			common.Exit(common.ExitUsage, err)
 		}
-	}()
-	// Configure Ouroboros
-	o, err := ouroboros.NewConnection(
-		ouroboros.WithNetworkMagic(cfg.NetworkMagic),
-		ouroboros.WithErrorChan(errorChan),
-		ouroboros.WithNodeToNode(true),
-		ouroboros.WithKeepAlive(true),
+	}
+	// Build set of allowed datum hashes, if provided
+	datumHashes := make(map[string]bool)
+	for _, datumHash := range cfg.DatumHash {
+		datumHashes[strings.ToLower(datumHash)] = true
+	}
+	if err := common.CheckNodeAddress(cfg.Address); err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if cfg.Timeout <= 0 {
+		common.Exit(
+			common.ExitUsage,
+			errors.New("timeout must be greater than zero"),
+		)
+	}
+	// Configure NetworkMagic
+	cfg.NetworkMagic = common.SharedNetworkMagic(
+		nodeCfg,
+		cfg.Network,
+		cfg.NetworkMagic,
+		cfg.Address,
 	)
+	networkMagic, err := common.NetworkMagic(cfg.Network, cfg.NetworkMagic)
 	if err != nil {
-		panic(err)
-	}
-	// Connect to Node address
-	if err = o.Dial("tcp", cfg.Address); err != nil {
-		panic(err)
+		common.Exit(common.ExitUsage, err)
 	}
+	cfg.NetworkMagic = networkMagic
+	common.WarnDefaultNodeMagic(cfg.Address, cfg.NetworkMagic)
 	// Decode hash string into bytes
 	blockHash, err := hex.DecodeString(cfg.Hash)
 	if err != nil {
-		panic(err)
+		common.Exit(common.ExitUsage, err)
 	}
 	// Get requested block from Node via NtN BlockFetch
-	block, err := o.BlockFetch().Client.GetBlock(
+	block, err := fetchBlockWithRetry(
+		cfg,
 		ocommon.NewPoint(cfg.Slot, blockHash),
 	)
-	if block == nil {
-		panic("empty block! this shouldn't happen")
-	}
 	if err != nil {
-		panic(err)
+		common.Exit(common.ExitProtocol, err)
 	}
 	// Check if we want CBOR or text output
 	if cfg.ReturnCbor {
@@ -109,6 +151,13 @@ func main() {
 		)
 		os.Exit(0)
 	}
+	// Check if we want templated output
+	if tmpl != nil {
+		if err := renderBlockTemplate(tmpl, block); err != nil {
+			common.Exit(common.ExitUsage, err)
+		}
+		os.Exit(0)
+	}
 
 	// Display simple block info
 
@@ -144,17 +193,34 @@ func main() {
 		block.IssuerVkey().PoolId(),
 		block.IssuerVkey().Hash(),
 	)
+	// Consensus info
+	if cfg.Consensus {
+		printConsensusInfo(block)
+	}
 	// Transactions
-	fmt.Println("Transactions:")
+	common.Infoln("Transactions:")
 	for _, tx := range block.Transactions() {
+		// Skip transactions without any datums, if requested
+		if cfg.HasDatum && !txHasDatum(tx) {
+			continue
+		}
 		fmt.Printf("- Hash: %s\n", tx.Hash())
 		// Show metadata, if present
 		if tx.Metadata() != nil {
-			fmt.Printf(
-				"  Metadata: %#v (%x)\n",
-				tx.Metadata().Value(),
-				tx.Metadata().Cbor(),
-			)
+			diag, ok := cborDiag(cfg, tx.Metadata().Cbor())
+			if ok {
+				fmt.Printf("  Metadata: (diag) %s\n", diag)
+			} else {
+				fmt.Printf(
+					"  Metadata: %#v (%x)\n",
+					tx.Metadata().Value(),
+					tx.Metadata().Cbor(),
+				)
+			}
+			// Show CIP-25 NFT metadata, if requested and present
+			if cfg.Nft {
+				printNftMetadata(tx.Metadata().Cbor())
+			}
 		}
 		// Inputs
 		if len(tx.Inputs()) > 0 {
@@ -169,8 +235,23 @@ func main() {
 		}
 		// Outputs
 		if len(tx.Outputs()) > 0 {
-			fmt.Println("  Outputs:")
+			witnessDatums := txWitnessDatums(tx)
+			// Only show the header once an output passes the filters
+			printedHeader := false
 			for _, output := range tx.Outputs() {
+				// Skip pure-ADA outputs, if requested
+				if cfg.AssetsOnly && !common.HasAssets(output) {
+					continue
+				}
+				// Skip outputs without an allowed datum hash, if requested
+				if len(datumHashes) > 0 &&
+					!datumHashes[outputDatumHash(output)] {
+					continue
+				}
+				if !printedHeader {
+					fmt.Println("  Outputs:")
+					printedHeader = true
+				}
 				// Output our normal address and amount, in all transactions
 				fmt.Printf(
 					"  - address = %s, amount = %d, cbor (hex) = %x\n",
@@ -182,33 +263,39 @@ func main() {
 				assets := output.Assets()
 				if assets != nil {
 					fmt.Println("  - Assets:")
-					for _, policyId := range assets.Policies() {
-						for _, assetName := range assets.Assets(policyId) {
-							fmt.Printf(
-								"    - Asset: name = %s, amount = %d, policy = %s\n",
-								assetName,
-								assets.Asset(policyId, assetName),
-								policyId,
-							)
-						}
-					}
+					common.PrintAssets(assets, "    ", cfg.FlatAssets)
 				}
-				// Check for optional datum
-				datum := output.Datum()
-				if datum != nil {
-					jsonData, err := json.Marshal(datum)
-					if err != nil {
-						fmt.Printf(
-							"  - Datum: (hex) %x\n",
-							datum.Cbor(),
-						)
-					} else {
+				// Check for optional reference script
+				scriptRef, err := outputScriptRef(output)
+				if err != nil {
+					fmt.Printf(
+						"  - Reference script: (failed to decode: %s)\n",
+						err,
+					)
+				} else if scriptRef != nil {
+					fmt.Printf(
+						"  - Reference script: type = %s, hash = %s, size = %d\n",
+						scriptRef.Type,
+						scriptRef.Hash,
+						len(scriptRef.Bytes),
+					)
+					if cfg.DumpScript {
 						fmt.Printf(
-							"  - Datum: %s\n",
-							jsonData,
+							"    script (hex) = %x\n",
+							scriptRef.Bytes,
 						)
 					}
 				}
+				// Check for optional datum, either inline or by hash
+				if datum := output.Datum(); datum != nil {
+					printDatum(cfg, datum)
+				} else if datumHash := common.DatumHash(output); datumHash != nil {
+					fmt.Printf("  - Datum hash: %s\n", datumHash)
+					// The datum is often included in the witness set
+					if datum, ok := witnessDatums[datumHash.String()]; ok {
+						printDatum(cfg, datum)
+					}
+				}
 			}
 		}
 		// Collateral
@@ -229,21 +316,97 @@ func main() {
 				fmt.Printf("  - %T\n", cert)
 			}
 		}
+		// Witnesses
+		if counts, ok := txWitnessCounts(tx); ok {
+			fmt.Printf(
+				"  Witnesses: vkey = %d, bootstrap = %d, native scripts = %d, plutus scripts = %d, plutus data = %d, redeemers = %d\n",
+				counts.VkeyWitnesses,
+				counts.BootstrapWitnesses,
+				counts.NativeScripts,
+				counts.PlutusScripts,
+				counts.PlutusData,
+				counts.Redeemers,
+			)
+		}
 		// Asset mints
 		if tx.AssetMint() != nil {
 			fmt.Println("  Asset mints:")
-			assets := tx.AssetMint()
-			for _, policyId := range assets.Policies() {
-				for _, assetName := range assets.Assets(policyId) {
-					fmt.Printf(
-						"    - Asset: name = %s, amount = %d, policy = %s\n",
-						assetName,
-						assets.Asset(policyId, assetName),
-						policyId,
-					)
-				}
-			}
+			common.PrintAssets(tx.AssetMint(), "    ", cfg.FlatAssets)
 		}
 	}
 	fmt.Println()
 }
+
+// Display CIP-25 NFT metadata from the provided transaction metadata CBOR
+func printNftMetadata(metadataCbor []byte) {
+	nfts, err := decodeCip25Metadata(metadataCbor)
+	if err != nil || len(nfts) == 0 {
+		// Skip metadata which isn't valid CIP-25
+		return
+	}
+	fmt.Println("  NFTs (CIP-25):")
+	for _, nft := range nfts {
+		fmt.Printf(
+			"  - policy = %s, asset = %s\n",
+			nft.PolicyId,
+			nft.AssetName,
+		)
+		fmt.Printf("    name = %s\n", nft.Name)
+		fmt.Printf("    image = %s\n", nft.Image)
+		if nft.MediaType != "" {
+			fmt.Printf("    mediaType = %s\n", nft.MediaType)
+		}
+		if nft.Description != "" {
+			fmt.Printf("    description = %s\n", nft.Description)
+		}
+	}
+}
+
+// Return the hash of the output's datum as a hex string. This is either the
+// datum hash in the output, or computed from the inline datum. It returns an
+// empty string if the output has no datum
+func outputDatumHash(output ledger.TransactionOutput) string {
+	if datumHash := common.DatumHash(output); datumHash != nil {
+		return datumHash.String()
+	}
+	if datum := output.Datum(); datum != nil {
+		return lcommon.Blake2b256Hash(datum.Cbor()).String()
+	}
+	return ""
+}
+
+// Check whether any of the transaction's outputs carry a datum
+func txHasDatum(tx ledger.Transaction) bool {
+	for _, output := range tx.Outputs() {
+		if common.HasDatum(output) {
+			return true
+		}
+	}
+	return false
+}
+
+// Display a datum in diagnostic notation if requested, or as JSON, falling
+// back to hex
+func printDatum(cfg Config, datum *gcbor.LazyValue) {
+	if diag, ok := cborDiag(cfg, datum.Cbor()); ok {
+		fmt.Printf("  - Datum: (diag) %s\n", diag)
+	} else if jsonData, err := json.Marshal(datum); err != nil {
+		fmt.Printf("  - Datum: (hex) %x\n", datum.Cbor())
+	} else {
+		fmt.Printf("  - Datum: %s\n", jsonData)
+	}
+}
+
+// Render the provided CBOR in diagnostic notation, if requested. It returns
+// false if not requested or the CBOR can't be rendered, in which case the
+// caller falls back to its default output
+func cborDiag(cfg Config, cborData []byte) (string, bool) {
+	if !cfg.CborDiag {
+		return "", false
+	}
+	diag, err := cbor.Diagnose(cborData)
+	if err != nil {
+		return "", false
+	}
+	return diag, true
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Script types used in reference scripts, which are also the prefix byte
+// used when computing the script hash
+var scriptTypeNames = map[uint8]string{
+	0: "native",
+	1: "PlutusV1",
+	2: "PlutusV2",
+	3: "PlutusV3",
+}
+
+// ScriptRef describes a reference script attached to a transaction output
+type ScriptRef struct {
+	Type  string
+	Hash  string
+	Bytes []byte
+}
+
+// outputScriptRef returns the reference script attached to the output, or nil
+// if it has none. Reference scripts were introduced in Babbage, and are not
+// exposed by the ledger.TransactionOutput interface
+func outputScriptRef(output ledger.TransactionOutput) (*ScriptRef, error) {
+	babbageOutput, ok := output.(*ledger.BabbageTransactionOutput)
+	if !ok || babbageOutput.ScriptRef == nil {
+		return nil, nil
+	}
+	// The script reference is wrapped in tag 24 (encoded CBOR data item)
+	scriptRefCbor, ok := babbageOutput.ScriptRef.Content.([]byte)
+	if !ok {
+		return nil, errors.New("unexpected script reference content")
+	}
+	var scriptRef []cbor.RawMessage
+	if err := cbor.Unmarshal(scriptRefCbor, &scriptRef); err != nil {
+		return nil, err
+	}
+	if len(scriptRef) != 2 {
+		return nil, errors.New("unexpected script reference length")
+	}
+	var scriptType uint8
+	if err := cbor.Unmarshal(scriptRef[0], &scriptType); err != nil {
+		return nil, err
+	}
+	typeName, ok := scriptTypeNames[scriptType]
+	if !ok {
+		return nil, fmt.Errorf("unknown script type: %d", scriptType)
+	}
+	// Native scripts are hashed using their CBOR, while Plutus scripts are
+	// hashed using the script bytes
+	scriptBytes := []byte(scriptRef[1])
+	if scriptType != 0 {
+		if err := cbor.Unmarshal(scriptRef[1], &scriptBytes); err != nil {
+			return nil, err
+		}
+	}
+	hash := lcommon.Blake2b224Hash(
+		append([]byte{scriptType}, scriptBytes...),
+	)
+	return &ScriptRef{
+		Type:  typeName,
+		Hash:  hash.String(),
+		Bytes: scriptBytes,
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"text/template"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// templateUsage describes the output template, for the -help output
+const templateUsage = `Output template:
+  Set BLOCK_FETCH_TEMPLATE to a Go text/template to format the block instead
+  of the default output. The available fields are:
+    Era          era name
+    Slot         slot number
+    BlockNumber  block number
+    Hash         block hash
+    PrevHash     previous block hash
+    Issuer       pool ID of the block issuer
+    BodySize     block body size in bytes
+    TxCount      number of transactions
+    TxHashes     list of transaction hashes
+
+  Examples:
+    BLOCK_FETCH_TEMPLATE='{{.Era}} {{.Slot}} {{.Hash}}' block-fetch
+    BLOCK_FETCH_TEMPLATE='{{range .TxHashes}}{{.}}{{"\n"}}{{end}}' block-fetch
+`
+
+// BlockTemplateData contains the block fields available to an output template
+type BlockTemplateData struct {
+	Era         string
+	Slot        uint64
+	BlockNumber uint64
+	Hash        string
+	PrevHash    string
+	Issuer      string
+	BodySize    uint64
+	TxCount     int
+	TxHashes    []string
+}
+
+// newBlockTemplateData populates the template fields from the provided block
+func newBlockTemplateData(block ledger.Block) BlockTemplateData {
+	data := BlockTemplateData{
+		Era:         block.Era().Name,
+		Slot:        block.SlotNumber(),
+		BlockNumber: block.BlockNumber(),
+		Hash:        block.Hash(),
+		PrevHash:    block.PrevHash(),
+		Issuer:      block.IssuerVkey().PoolId(),
+		BodySize:    block.BlockBodySize(),
+		TxCount:     len(block.Transactions()),
+	}
+	for _, tx := range block.Transactions() {
+		data.TxHashes = append(data.TxHashes, tx.Hash())
+	}
+	return data
+}
+
+// renderBlockTemplate writes the block to stdout using the provided template
+func renderBlockTemplate(tmpl *template.Template, block ledger.Block) error {
+	if err := tmpl.Execute(os.Stdout, newBlockTemplateData(block)); err != nil {
+		return err
+	}
+	_, err := os.Stdout.WriteString("\n")
+	return err
+}
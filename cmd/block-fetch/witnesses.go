@@ -0,0 +1,105 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	gcbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// TxWitnessCounts summarizes the witnesses attached to a transaction
+type TxWitnessCounts struct {
+	VkeyWitnesses      int
+	BootstrapWitnesses int
+	NativeScripts      int
+	PlutusScripts      int
+	PlutusData         int
+	Redeemers          int
+}
+
+// txWitnessCounts returns the witness counts for the provided transaction.
+// The witness set is not exposed by the ledger.Transaction interface, so we
+// need to check each era's transaction type. It returns false for
+// transactions without a witness set we know about, such as Byron
+func txWitnessCounts(tx ledger.Transaction) (TxWitnessCounts, bool) {
+	var counts TxWitnessCounts
+	switch v := tx.(type) {
+	case *ledger.ShelleyTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+	case *ledger.AllegraTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+	case *ledger.MaryTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+	case *ledger.AlonzoTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+		counts.PlutusScripts = len(v.WitnessSet.PlutusScripts)
+		counts.PlutusData = len(v.WitnessSet.PlutusData)
+		counts.Redeemers = len(v.WitnessSet.Redeemers)
+	case *ledger.BabbageTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+		counts.PlutusScripts = len(v.WitnessSet.PlutusScripts) +
+			len(v.WitnessSet.PlutusV2Scripts)
+		counts.PlutusData = len(v.WitnessSet.PlutusData)
+		counts.Redeemers = len(v.WitnessSet.Redeemers)
+	case *ledger.ConwayTransaction:
+		counts.VkeyWitnesses = len(v.WitnessSet.VkeyWitnesses)
+		counts.BootstrapWitnesses = len(v.WitnessSet.BootstrapWitnesses)
+		counts.NativeScripts = len(v.WitnessSet.MultisigScripts)
+		counts.PlutusScripts = len(v.WitnessSet.PlutusScripts) +
+			len(v.WitnessSet.PlutusV2Scripts) +
+			len(v.WitnessSet.PlutusV3Scripts)
+		counts.PlutusData = len(v.WitnessSet.PlutusData)
+		counts.Redeemers = len(v.WitnessSet.Redeemers.Redeemers)
+	default:
+		return counts, false
+	}
+	return counts, true
+}
+
+// txWitnessDatums returns the Plutus data from the transaction's witness set,
+// keyed by datum hash, so that outputs which only carry a datum hash can show
+// the datum itself. The hash is computed from the original CBOR bytes, which
+// is what the datum hash commits to
+func txWitnessDatums(tx ledger.Transaction) map[string]*gcbor.LazyValue {
+	var plutusData []gcbor.RawMessage
+	switch v := tx.(type) {
+	case *ledger.AlonzoTransaction:
+		plutusData = v.WitnessSet.PlutusData
+	case *ledger.BabbageTransaction:
+		plutusData = v.WitnessSet.PlutusData
+	case *ledger.ConwayTransaction:
+		plutusData = v.WitnessSet.PlutusData
+	}
+	datums := make(map[string]*gcbor.LazyValue)
+	for _, datumCbor := range plutusData {
+		var datum gcbor.LazyValue
+		if err := datum.UnmarshalCBOR(datumCbor); err != nil {
+			continue
+		}
+		datums[lcommon.Blake2b256Hash(datumCbor).String()] = &datum
+	}
+	return datums
+}
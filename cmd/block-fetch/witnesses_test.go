@@ -0,0 +1,44 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	gcbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func TestTxWitnessDatums(t *testing.T) {
+	// Constr 0 ["hello", 1000], encoded with an indefinite-length list so
+	// that re-encoding would change the hash
+	datumCbor, err := hex.DecodeString("d8799f4568656c6c6f1903e8ff")
+	if err != nil {
+		t.Fatalf("unexpected error decoding datum: %s", err)
+	}
+	tx := &ledger.AlonzoTransaction{}
+	tx.WitnessSet.PlutusData = []gcbor.RawMessage{datumCbor}
+	datums := txWitnessDatums(tx)
+	datumHash := lcommon.Blake2b256Hash(datumCbor).String()
+	datum, ok := datums[datumHash]
+	if !ok {
+		t.Fatalf("expected datum with hash %s, got: %v", datumHash, datums)
+	}
+	if hex.EncodeToString(datum.Cbor()) != hex.EncodeToString(datumCbor) {
+		t.Errorf("expected datum CBOR %x, got %x", datumCbor, datum.Cbor())
+	}
+}
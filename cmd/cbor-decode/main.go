@@ -0,0 +1,242 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	gcbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// We parse environment variables using envconfig into this struct
+type Config struct {
+	Hex  string
+	Type string
+}
+
+// Returned when there is no CBOR to decode
+var errNoCbor = errors.New(
+	"no CBOR provided with -hex, in CBOR_DECODE_HEX, or on stdin",
+)
+
+// This code will be executed when run
+func main() {
+	// Set config defaults
+	var cfg = Config{
+		Type: "",
+	}
+	// Parse command line flags, which take precedence over the environment
+	hexFlag := flag.String(
+		"hex",
+		"",
+		"hex-encoded CBOR to decode (default CBOR_DECODE_HEX, or stdin)",
+	)
+	typeFlag := flag.String(
+		"type",
+		"",
+		"type of CBOR: block, tx, or datum (default CBOR_DECODE_TYPE, or detected)",
+	)
+	common.ParseOfflineFlags()
+	// Parse environment variables
+	if err := envconfig.Process("cbor_decode", &cfg); err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if *hexFlag != "" {
+		cfg.Hex = *hexFlag
+	}
+	if *typeFlag != "" {
+		cfg.Type = *typeFlag
+	}
+	// Read the hex CBOR from stdin when not provided
+	hexData := cfg.Hex
+	if hexData == "" {
+		// Don't wait for input which is never coming when run interactively
+		if stdinIsTerminal() {
+			flag.Usage()
+			common.Exit(common.ExitUsage, errNoCbor)
+		}
+		stdinData, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			common.Exit(common.ExitError, err)
+		}
+		hexData = string(stdinData)
+	}
+	// Ignore any whitespace, such as line wrapping from xxd
+	hexData = strings.Join(strings.Fields(hexData), "")
+	cborData, err := hex.DecodeString(hexData)
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if len(cborData) == 0 {
+		common.Exit(common.ExitUsage, errNoCbor)
+	}
+	// Detect the type of CBOR when not specified, trying the most
+	// specific decoders first
+	cborType := cfg.Type
+	if cborType == "" {
+		cborType = detectType(cborData)
+		common.Logf("detected type: %s", cborType)
+	}
+	switch cborType {
+	case "block":
+		err = printBlock(cborData)
+	case "tx":
+		err = printTx(cborData)
+	case "datum":
+		err = printDatum(cborData)
+	default:
+		common.Exit(
+			common.ExitUsage,
+			fmt.Errorf(
+				"invalid type specified: %s (expected block, tx, or datum)",
+				cborType,
+			),
+		)
+	}
+	if err != nil {
+		common.Exit(common.ExitError, err)
+	}
+}
+
+// Returns whether stdin is a terminal rather than a pipe or file
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Determine whether the CBOR is a block, a transaction, or other data which
+// we treat as a datum
+func detectType(cborData []byte) string {
+	if _, err := ledger.DetermineBlockType(cborData); err == nil {
+		return "block"
+	}
+	if _, err := ledger.DetermineTransactionType(cborData); err == nil {
+		return "tx"
+	}
+	return "datum"
+}
+
+// Display a summary of a block and its transactions
+func printBlock(cborData []byte) error {
+	blockType, err := ledger.DetermineBlockType(cborData)
+	if err != nil {
+		return err
+	}
+	block, err := ledger.NewBlockFromCbor(blockType, cborData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(
+		"Block: era = %s, slot = %d, block_no = %d, id = %s\n",
+		block.Era().Name,
+		block.SlotNumber(),
+		block.BlockNumber(),
+		block.Hash(),
+	)
+	fmt.Printf("Previous block: %s\n", block.PrevHash())
+	fmt.Printf("Body size: %d\n", block.BlockBodySize())
+	common.Infoln("Transactions:")
+	for _, tx := range block.Transactions() {
+		fmt.Printf("- Hash: %s\n", tx.Hash())
+	}
+	return nil
+}
+
+// Display the details of a transaction
+func printTx(cborData []byte) error {
+	txType, err := ledger.DetermineTransactionType(cborData)
+	if err != nil {
+		return err
+	}
+	tx, err := ledger.NewTransactionFromCbor(txType, cborData)
+	if err != nil {
+		return err
+	}
+	// The era is a best guess from the transaction's structure, since the
+	// same CBOR can be valid in several eras
+	fmt.Printf(
+		"Transaction: detected era = %s, id = %s\n",
+		ledger.GetEraById(uint8(txType)).Name,
+		tx.Hash(),
+	)
+	fmt.Printf("Fee: %d\n", tx.Fee())
+	if len(tx.Inputs()) > 0 {
+		fmt.Println("Inputs:")
+		for _, input := range tx.Inputs() {
+			fmt.Printf(
+				"- index = %d, id = %s\n",
+				input.Index(),
+				input.Id(),
+			)
+		}
+	}
+	if len(tx.Outputs()) > 0 {
+		fmt.Println("Outputs:")
+		for _, output := range tx.Outputs() {
+			fmt.Printf(
+				"- address = %s, amount = %d\n",
+				output.Address(),
+				output.Amount(),
+			)
+			assets := output.Assets()
+			if assets == nil {
+				continue
+			}
+			common.PrintAssets(assets, "  ", false)
+		}
+	}
+	if tx.Metadata() != nil {
+		diag, err := cbor.Diagnose(tx.Metadata().Cbor())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Metadata: %s\n", diag)
+	}
+	return nil
+}
+
+// Display arbitrary CBOR data, such as a Plutus datum, in diagnostic
+// notation and as JSON
+func printDatum(cborData []byte) error {
+	diag, err := cbor.Diagnose(cborData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Diagnostic: %s\n", diag)
+	var value gcbor.Value
+	if err := value.UnmarshalCBOR(cborData); err != nil {
+		return err
+	}
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("JSON: %s\n", jsonData)
+	return nil
+}
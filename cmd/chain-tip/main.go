@@ -15,60 +15,97 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
-	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
 	"github.com/kelseyhightower/envconfig"
 )
 
-// We parse environment variables using envconfig into this struct
-type Config struct {
-	Magic      uint32
-	SocketPath string `split_words:"true"`
+// Options specific to this tool are parsed from CHAIN_TIP_* environment
+// variables into this struct
+type TipConfig struct {
+	Count    uint
+	Interval time.Duration
 }
 
 // This code will be executed when run
 func main() {
-	// Set config defaults
-	var cfg = Config{
-		Magic:      764824073,
-		SocketPath: "/ipc/node.socket",
+	// Parse command line flags
+	common.ParseFlags()
+	// Parse the shared CARDANO_NODE_* environment variables
+	cfg, err := common.LoadLocalNodeConfig()
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
 	}
-	// Parse environment variables
-	if err := envconfig.Process("cardano_node", &cfg); err != nil {
-		panic(err)
+	var tipCfg = TipConfig{
+		Count:    1,
+		Interval: 2 * time.Second,
 	}
-	// Create error channel
-	errorChan := make(chan error)
-	// start error handler
-	go func() {
-		for {
-			err := <-errorChan
-			panic(err)
-		}
-	}()
-	// Configure Ouroboros
-	o, err := ouroboros.NewConnection(
-		ouroboros.WithNetworkMagic(uint32(cfg.Magic)),
-		ouroboros.WithErrorChan(errorChan),
-		ouroboros.WithNodeToNode(false),
-	)
-	if err != nil {
-		panic(err)
+	if err := envconfig.Process("chain_tip", &tipCfg); err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if tipCfg.Count == 0 {
+		common.Exit(
+			common.ExitUsage,
+			errors.New("count must be greater than zero"),
+		)
 	}
 	// Connect to Node socket
-	if err = o.Dial("unix", cfg.SocketPath); err != nil {
-		panic(err)
+	o, errorChan, err := common.ConnectLocal(cfg)
+	if err != nil {
+		common.Exit(common.ExitProtocol, err)
 	}
+	common.ExitOnAsyncError(errorChan)
 	// Get current tip from Node via NtC ChainSync Ouroboros mini-protocol
-	tip, err := o.ChainSync().Client.GetCurrentTip()
-	if err != nil {
-		panic(err)
+	common.Infoln("Chain Tip:")
+	var minSlot, maxSlot, prevSlot uint64
+	for i := uint(0); i < tipCfg.Count; i++ {
+		// Wait between samples
+		if i > 0 {
+			time.Sleep(tipCfg.Interval)
+		}
+		tip, err := o.ChainSync().Client.GetCurrentTip()
+		if err != nil {
+			common.Exit(common.ExitProtocol, err)
+		}
+		fmt.Printf(
+			"Slot: %-10d Block Hash: %x",
+			tip.Point.Slot,
+			tip.Point.Hash,
+		)
+		if i > 0 {
+			// The tip can move back to a lower slot when switching forks
+			fmt.Printf(" (%+d)", int64(tip.Point.Slot)-int64(prevSlot))
+		}
+		fmt.Println()
+		if i == 0 || tip.Point.Slot < minSlot {
+			minSlot = tip.Point.Slot
+		}
+		if tip.Point.Slot > maxSlot {
+			maxSlot = tip.Point.Slot
+		}
+		prevSlot = tip.Point.Slot
+	}
+	common.Infoln()
+	// Report how far the tip moved across multiple samples
+	if tipCfg.Count > 1 {
+		fmt.Printf(
+			"Summary: samples = %d, min slot = %d, max slot = %d, delta = %d\n",
+			tipCfg.Count,
+			minSlot,
+			maxSlot,
+			maxSlot-minSlot,
+		)
+		if maxSlot == minSlot {
+			common.Exit(
+				common.ExitAlert,
+				fmt.Errorf(
+					"node appears stalled: tip did not advance across %d samples",
+					tipCfg.Count,
+				),
+			)
+		}
 	}
-	fmt.Printf(
-		"Chain Tip:\nSlot: %-10d Block Hash: %x\n",
-		tip.Point.Slot,
-		tip.Point.Hash,
-	)
-	fmt.Println()
 }
@@ -0,0 +1,61 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// PrintAssets displays a multi-asset value, either grouped by policy with the
+// policy ID printed once, or flat with the policy repeated on each asset line.
+// Each line starts with the provided prefix, and each asset includes its
+// CIP-14 fingerprint
+func PrintAssets[T lcommon.MultiAssetTypeOutput | lcommon.MultiAssetTypeMint](
+	assets *lcommon.MultiAsset[T],
+	prefix string,
+	flat bool,
+) {
+	for _, policyId := range assets.Policies() {
+		if !flat {
+			fmt.Printf("%s- Policy: %s\n", prefix, policyId)
+		}
+		for _, assetName := range assets.Assets(policyId) {
+			fingerprint := lcommon.NewAssetFingerprint(
+				policyId.Bytes(),
+				assetName,
+			)
+			if flat {
+				fmt.Printf(
+					"%s- Asset: name = %s, amount = %d, policy = %s, fingerprint = %s\n",
+					prefix,
+					assetName,
+					assets.Asset(policyId, assetName),
+					policyId,
+					fingerprint.String(),
+				)
+				continue
+			}
+			fmt.Printf(
+				"%s  - %s = %d (%s)\n",
+				prefix,
+				assetName,
+				assets.Asset(policyId, assetName),
+				fingerprint.String(),
+			)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"os"
+)
+
+// ANSI escape codes for the styles we use
+const (
+	styleBold   = "1"
+	styleRed    = "31"
+	styleYellow = "33"
+)
+
+// Color modes accepted by the -color flag
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+var colorMode = ColorAuto
+
+// SetColor sets the color mode. In auto mode, color is used only when writing
+// to a terminal and the NO_COLOR environment variable is not set
+func SetColor(mode string) error {
+	switch mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		colorMode = mode
+		return nil
+	}
+	return fmt.Errorf(
+		"invalid color mode specified: %s (expected auto, always, or never)",
+		mode,
+	)
+}
+
+// colorEnabled returns whether color should be used when writing to the file
+func colorEnabled(f *os.File) bool {
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps the text in the provided style, if color is enabled for the
+// file it will be written to
+func colorize(f *os.File, style string, text string) string {
+	if text == "" || !colorEnabled(f) {
+		return text
+	}
+	return "\033[" + style + "m" + text + "\033[0m"
+}
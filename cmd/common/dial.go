@@ -0,0 +1,132 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+)
+
+// DefaultDialTimeout is how long to wait when connecting to a Node
+const DefaultDialTimeout = 10 * time.Second
+
+var dialTimeout = DefaultDialTimeout
+
+// SetDialTimeout sets how long to wait when connecting to a Node
+func SetDialTimeout(timeout time.Duration) {
+	dialTimeout = timeout
+}
+
+// DialNode connects the Ouroboros connection to the Node at the provided TCP
+// address and performs the handshake, giving up after the dial timeout
+func DialNode(o *ouroboros.Connection, address string) error {
+	return withDialTimeout(
+		func() error {
+			return dialError(o.DialTimeout("tcp", address, dialTimeout))
+		},
+		func() {
+			_ = o.Close()
+		},
+	)
+}
+
+// ConnectLocal connects to the local Node socket from the config and performs
+// the Node-to-Client handshake, giving up after the dial timeout. Any extra
+// options, such as mini-protocol configs, are applied after the defaults.
+// Async errors from the connection are sent on the returned channel
+func ConnectLocal(
+	cfg NodeConfig,
+	options ...ouroboros.ConnectionOptionFunc,
+) (*ouroboros.Connection, <-chan error, error) {
+	conn, err := DialLocal(cfg.SocketPath)
+	if err != nil {
+		return nil, nil, &ConnectionError{Err: err}
+	}
+	errorChan := make(chan error, 10)
+	options = append(
+		[]ouroboros.ConnectionOptionFunc{
+			ouroboros.WithConnection(conn),
+			ouroboros.WithNetworkMagic(cfg.Magic),
+			ouroboros.WithErrorChan(errorChan),
+			ouroboros.WithLogger(Logger()),
+			ouroboros.WithNodeToNode(false),
+		},
+		options...,
+	)
+	// The handshake is performed when creating the connection
+	var o *ouroboros.Connection
+	err = withDialTimeout(
+		func() error {
+			var err error
+			o, err = ouroboros.NewConnection(options...)
+			return err
+		},
+		func() {
+			_ = conn.Close()
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o, errorChan, nil
+}
+
+// ExitOnAsyncError starts a goroutine which exits on the first async error
+// from the connection. Errors from the connection itself exit with
+// ExitConnection, and others, such as mini-protocol errors, with ExitProtocol
+func ExitOnAsyncError(errorChan <-chan error) {
+	go func() {
+		err, ok := <-errorChan
+		// The channel is closed when the connection is shut down
+		if !ok {
+			return
+		}
+		Exit(ExitProtocol, WrapConnectionError(err))
+	}()
+}
+
+// withDialTimeout runs the connect function, which also performs the
+// handshake, giving up after the dial timeout. The abort function is called
+// on timeout, so that the connect function returns rather than blocking
+// forever on a Node which never responds
+func withDialTimeout(connect func() error, abort func()) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- connect()
+	}()
+	select {
+	case err := <-errChan:
+		return err
+	case <-time.After(dialTimeout):
+		abort()
+		return fmt.Errorf(
+			"connection timed out after %s: %w",
+			dialTimeout,
+			context.DeadlineExceeded,
+		)
+	}
+}
+
+// dialError replaces the low-level error for a dial timeout with a clearer
+// one. It still wraps the original, so it is classified as a timeout
+func dialError(err error) error {
+	if err != nil && IsTimeout(err) {
+		return fmt.Errorf("connection timed out after %s: %w", dialTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package common
+
+import (
+	"net"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// Prefix used by Windows named pipe paths, such as \\.\pipe\cardano-node
+const namedPipePrefix = `\\.\pipe\`
+
+// DialLocal connects to the local Cardano Node at the given path. On Windows,
+// cardano-node listens on a named pipe rather than a UNIX socket, so we detect
+// named pipe paths and dial them using the named pipe transport
+func DialLocal(path string) (net.Conn, error) {
+	var conn net.Conn
+	var err error
+	if strings.HasPrefix(path, namedPipePrefix) {
+		timeout := dialTimeout
+		conn, err = winio.DialPipe(path, &timeout)
+	} else {
+		conn, err = net.DialTimeout("unix", path, dialTimeout)
+	}
+	return conn, dialError(err)
+}
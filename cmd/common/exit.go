@@ -0,0 +1,111 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+)
+
+// Exit codes used by all commands, so that scripts can distinguish between
+// the different kinds of failures
+const (
+	ExitSuccess    = 0
+	ExitError      = 1 // unclassified error
+	ExitUsage      = 2 // invalid configuration or arguments
+	ExitConnection = 3 // failed to connect to the node
+	ExitProtocol   = 4 // protocol failure or request rejected by the node
+	ExitTimeout    = 5 // timed out waiting for the node
+	ExitAlert      = 6 // an alert threshold was exceeded or the node is stalled
+)
+
+// ExitCodeDescriptions describes each exit code, for the -help output
+var ExitCodeDescriptions = []struct {
+	Code    int
+	Meaning string
+}{
+	{ExitSuccess, "success"},
+	{ExitError, "unclassified error"},
+	{ExitUsage, "invalid configuration or arguments"},
+	{ExitConnection, "failed to connect to the node"},
+	{ExitProtocol, "protocol failure or request rejected by the node"},
+	{ExitTimeout, "timed out waiting for the node"},
+	{ExitAlert, "an alert threshold was exceeded or the node is stalled"},
+}
+
+// ConnectionError wraps an error which occurred while connecting to the node,
+// for code paths which return errors from both connecting and querying
+type ConnectionError struct {
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("connection failed: %s", e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// WrapConnectionError wraps the error in a ConnectionError when it came from
+// the network connection itself, such as a failed dial or the node closing the
+// connection. Other errors, such as a refused handshake or a mini-protocol
+// error, are returned unchanged
+func WrapConnectionError(err error) error {
+	if err == nil || !isNetworkError(err) {
+		return err
+	}
+	return &ConnectionError{Err: err}
+}
+
+// isNetworkError returns whether the error came from the underlying network
+// connection. gOuroboros reports the connection being closed as a bare io.EOF
+func isNetworkError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) ||
+		errors.Is(err, io.EOF) ||
+		IsTimeout(err)
+}
+
+// Exit prints the error to stderr and exits with the provided exit code.
+// Timeout errors always exit with ExitTimeout and connection errors with
+// ExitConnection, regardless of the code given
+func Exit(code int, err error) {
+	fmt.Fprintln(os.Stderr, colorize(os.Stderr, styleRed, "error: "+err.Error()))
+	var connErr *ConnectionError
+	if IsTimeout(err) {
+		code = ExitTimeout
+	} else if errors.As(err, &connErr) {
+		code = ExitConnection
+	}
+	os.Exit(code)
+}
+
+// IsTimeout returns whether the error was caused by a timeout
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
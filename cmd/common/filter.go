@@ -0,0 +1,43 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// HasAssets returns whether the transaction output carries any native assets
+func HasAssets(output ledger.TransactionOutput) bool {
+	assets := output.Assets()
+	return assets != nil && len(assets.Policies()) > 0
+}
+
+// HasDatum returns whether the transaction output carries an inline datum or a
+// datum hash
+func HasDatum(output ledger.TransactionOutput) bool {
+	return output.Datum() != nil || DatumHash(output) != nil
+}
+
+// DatumHash returns the transaction output's datum hash, or nil if it has
+// none. Babbage outputs without a datum report an all-zero hash rather than
+// nil, so we treat that as no datum hash too
+func DatumHash(output ledger.TransactionOutput) *lcommon.Blake2b256 {
+	datumHash := output.DatumHash()
+	if datumHash == nil || *datumHash == (lcommon.Blake2b256{}) {
+		return nil
+	}
+	return datumHash
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const testAddress = "addr_test1qzfr6jmyu8tnpf9670ndcse6j6rfsw2q73vrv0eh4tt6r22k3de0s4fzujsh639ytngzrwt5rd2a0j7xxhy3zcjmq90qdn3muv"
+
+// Build a Babbage transaction output from its CBOR map form, so that it is
+// decoded the same way as outputs from a real block
+func newTestBabbageOutput(
+	t *testing.T,
+	datumOption any,
+) *babbage.BabbageTransactionOutput {
+	t.Helper()
+	addr, err := lcommon.NewAddress(testAddress)
+	if err != nil {
+		t.Fatalf("unexpected error decoding address: %s", err)
+	}
+	outputMap := map[uint]any{
+		0: addr.Bytes(),
+		1: uint64(1_000_000),
+	}
+	if datumOption != nil {
+		outputMap[2] = datumOption
+	}
+	outputCbor, err := cbor.Encode(outputMap)
+	if err != nil {
+		t.Fatalf("unexpected error encoding output: %s", err)
+	}
+	output, err := babbage.NewBabbageTransactionOutputFromCbor(outputCbor)
+	if err != nil {
+		t.Fatalf("unexpected error decoding output: %s", err)
+	}
+	return output
+}
+
+func TestHasDatumBabbageWithoutDatum(t *testing.T) {
+	output := newTestBabbageOutput(t, nil)
+	if HasDatum(output) {
+		t.Errorf("expected output without a datum to have no datum")
+	}
+	if datumHash := DatumHash(output); datumHash != nil {
+		t.Errorf("expected no datum hash, got %s", datumHash)
+	}
+}
+
+func TestHasDatumBabbageDatumHash(t *testing.T) {
+	hash := lcommon.Blake2b256Hash([]byte("datum"))
+	output := newTestBabbageOutput(t, []any{0, hash.Bytes()})
+	if !HasDatum(output) {
+		t.Errorf("expected output with a datum hash to have a datum")
+	}
+	datumHash := DatumHash(output)
+	if datumHash == nil || *datumHash != hash {
+		t.Errorf("expected datum hash %s, got %v", hash, datumHash)
+	}
+}
+
+func TestHasDatumBabbageInlineDatum(t *testing.T) {
+	// An inline datum is tag 24 wrapping the datum CBOR, here the integer 42
+	output := newTestBabbageOutput(
+		t,
+		[]any{1, cbor.Tag{Number: 24, Content: []byte{0x18, 0x2a}}},
+	)
+	if !HasDatum(output) {
+		t.Errorf("expected output with an inline datum to have a datum")
+	}
+	if datumHash := DatumHash(output); datumHash != nil {
+		t.Errorf("expected no datum hash, got %s", datumHash)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// usageEpilogue is shown at the end of the -help output
+var usageEpilogue string
+
+// SetUsageEpilogue sets additional text, such as examples, to show at the end
+// of the -help output. This should be called before parsing flags
+func SetUsageEpilogue(text string) {
+	usageEpilogue = text
+}
+
+// ParseFlags parses the command line flags shared by all commands. This
+// should be called before processing environment variables
+func ParseFlags() {
+	parseFlags(true)
+}
+
+// ParseOfflineFlags parses the shared command line flags for commands which
+// don't connect to a Node, leaving out the connection-related flags. Commands
+// may define their own flags before calling this
+func ParseOfflineFlags() {
+	parseFlags(false)
+}
+
+func parseFlags(connected bool) {
+	flag.Usage = usage
+	configFile := flag.String(
+		"config",
+		"",
+		"path to a YAML or JSON config file of environment variables",
+	)
+	envFile := flag.String(
+		"env-file",
+		"",
+		"path to a dotenv file of environment variables (default .env, if present)",
+	)
+	colorFlag := flag.String(
+		"color",
+		ColorAuto,
+		"when to use colored output: auto, always, or never",
+	)
+	dialTimeoutFlag := new(time.Duration)
+	listNetworks := new(bool)
+	traceMode := new(bool)
+	if connected {
+		flag.DurationVar(
+			dialTimeoutFlag,
+			"dial-timeout",
+			DefaultDialTimeout,
+			"how long to wait when connecting to a Node, including the handshake",
+		)
+		flag.BoolVar(
+			listNetworks,
+			"list-networks",
+			false,
+			"print the known network names and magics and exit",
+		)
+		flag.BoolVar(
+			traceMode,
+			"trace",
+			false,
+			"log protocol messages sent and received to stderr",
+		)
+	}
+	showVersion := flag.Bool(
+		"version",
+		false,
+		"print version information and exit",
+	)
+	quietMode := flag.Bool(
+		"quiet",
+		false,
+		"suppress informational output, showing only results and errors",
+	)
+	flag.Parse()
+	if err := SetColor(*colorFlag); err != nil {
+		Exit(ExitUsage, err)
+	}
+	if connected {
+		if *dialTimeoutFlag <= 0 {
+			Exit(
+				ExitUsage,
+				errors.New("dial timeout must be greater than zero"),
+			)
+		}
+		SetDialTimeout(*dialTimeoutFlag)
+	}
+	SetQuiet(*quietMode)
+	SetTrace(*traceMode)
+	if *showVersion {
+		fmt.Println(VersionString())
+		os.Exit(ExitSuccess)
+	}
+	if *listNetworks {
+		PrintNetworks()
+		os.Exit(ExitSuccess)
+	}
+	// Fall back to a .env file in the current directory, if there is one
+	if *envFile == "" {
+		if _, err := os.Stat(DefaultEnvFile); err == nil {
+			*envFile = DefaultEnvFile
+		}
+	}
+	if *envFile != "" {
+		if err := LoadEnvFile(*envFile); err != nil {
+			Exit(ExitUsage, err)
+		}
+	}
+	if *configFile != "" {
+		if err := LoadConfigFile(*configFile); err != nil {
+			Exit(ExitUsage, err)
+		}
+	}
+}
+
+// usage prints the flags, followed by the exit codes and any epilogue
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+	flag.PrintDefaults()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Exit codes:")
+	for _, exitCode := range ExitCodeDescriptions {
+		fmt.Fprintf(out, "  %d  %s\n", exitCode.Code, exitCode.Meaning)
+	}
+	if usageEpilogue != "" {
+		fmt.Fprintln(out)
+		fmt.Fprint(out, usageEpilogue)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// HeaderInfo holds the Praos fields from a Shelley or later block header
+type HeaderInfo struct {
+	BlockBodyHash lcommon.Blake2b256
+	VrfKey        []byte
+	LeaderVrf     lcommon.VrfResult
+	// Only present before Babbage, which uses a single VRF result for both
+	// leader election and the nonce
+	NonceVrf             *lcommon.VrfResult
+	OpCertSequenceNumber uint32
+	OpCertKesPeriod      uint32
+	ProtoMajorVersion    uint64
+	ProtoMinorVersion    uint64
+}
+
+// BlockHeaderInfo returns the Praos fields from the header of the provided
+// block. These are not exposed by the ledger.BlockHeader interface, so we
+// need to check each era's header type. It returns false for Byron blocks,
+// which predate Praos
+func BlockHeaderInfo(block ledger.Block) (HeaderInfo, bool) {
+	var header *ledger.ShelleyBlockHeader
+	switch v := block.(type) {
+	case *ledger.ShelleyBlock:
+		header = v.BlockHeader
+	case *ledger.AllegraBlock:
+		header = &v.BlockHeader.ShelleyBlockHeader
+	case *ledger.MaryBlock:
+		header = &v.BlockHeader.ShelleyBlockHeader
+	case *ledger.AlonzoBlock:
+		header = &v.BlockHeader.ShelleyBlockHeader
+	case *ledger.BabbageBlock:
+		return babbageHeaderInfo(v.BlockHeader), true
+	case *ledger.ConwayBlock:
+		return babbageHeaderInfo(&v.BlockHeader.BabbageBlockHeader), true
+	default:
+		return HeaderInfo{}, false
+	}
+	return HeaderInfo{
+		BlockBodyHash:        header.Body.BlockBodyHash,
+		VrfKey:               header.Body.VrfKey,
+		LeaderVrf:            header.Body.LeaderVrf,
+		NonceVrf:             &header.Body.NonceVrf,
+		OpCertSequenceNumber: header.Body.OpCertSequenceNumber,
+		OpCertKesPeriod:      header.Body.OpCertKesPeriod,
+		ProtoMajorVersion:    header.Body.ProtoMajorVersion,
+		ProtoMinorVersion:    header.Body.ProtoMinorVersion,
+	}, true
+}
+
+func babbageHeaderInfo(header *ledger.BabbageBlockHeader) HeaderInfo {
+	return HeaderInfo{
+		BlockBodyHash:        header.Body.BlockBodyHash,
+		VrfKey:               header.Body.VrfKey,
+		LeaderVrf:            header.Body.VrfResult,
+		OpCertSequenceNumber: header.Body.OpCert.SequenceNumber,
+		OpCertKesPeriod:      header.Body.OpCert.KesPeriod,
+		ProtoMajorVersion:    header.Body.ProtoVersion.Major,
+		ProtoMinorVersion:    header.Body.ProtoVersion.Minor,
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/protocol/keepalive"
+)
+
+// KeepAliveConfig builds the NtN keep-alive protocol config with the provided
+// interval and timeout. A zero value uses the gOuroboros default
+func KeepAliveConfig(
+	interval time.Duration,
+	timeout time.Duration,
+) keepalive.Config {
+	var opts []keepalive.KeepAliveOptionFunc
+	if interval > 0 {
+		opts = append(opts, keepalive.WithPeriod(interval))
+	}
+	if timeout > 0 {
+		opts = append(opts, keepalive.WithTimeout(timeout))
+	}
+	return keepalive.NewConfig(opts...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+)
+
+// DefaultNetwork is used when neither a network name nor magic is given
+const DefaultNetwork = "mainnet"
+
+// KnownNetworks lists the networks predefined by gOuroboros, which can be
+// selected by name. gOuroboros does not export its own list
+var KnownNetworks = []ouroboros.Network{
+	ouroboros.NetworkMainnet,
+	ouroboros.NetworkPreprod,
+	ouroboros.NetworkPreview,
+	ouroboros.NetworkSancho,
+}
+
+// NetworkMagic determines the network magic to use from a network name and an
+// explicit magic, either of which may be empty. When both are given, the magic
+// must match the named network, to avoid connecting to the wrong network
+func NetworkMagic(name string, magic uint32) (uint32, error) {
+	if name == "" {
+		if magic != 0 {
+			return magic, nil
+		}
+		name = DefaultNetwork
+	}
+	network, ok := ouroboros.NetworkByName(name)
+	if !ok {
+		return 0, fmt.Errorf("invalid network specified: %v", name)
+	}
+	if magic != 0 && magic != network.NetworkMagic {
+		return 0, fmt.Errorf(
+			"network magic %d does not match network %s (magic %d)",
+			magic,
+			name,
+			network.NetworkMagic,
+		)
+	}
+	return network.NetworkMagic, nil
+}
+
+// PrintNetworks displays the known network names and their magics
+func PrintNetworks() {
+	table := NewTable("NAME", "MAGIC")
+	for _, network := range KnownNetworks {
+		table.AddRow(network.Name, network.NetworkMagic)
+	}
+	if err := table.Flush(); err != nil {
+		Exit(ExitError, err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// DefaultNodeAddress is the mainnet relay used by the Node-to-Node commands
+// when no address is given
+const DefaultNodeAddress = "backbone.cardano.iog.io:3001"
+
+// NodeConfig holds the CARDANO_NODE_* environment variables, which configure
+// the Node connection the same way for all commands
+type NodeConfig struct {
+	Address    string
+	Magic      uint32
+	SocketPath string `split_words:"true"`
+}
+
+// LoadNodeConfig parses the CARDANO_NODE_* environment variables. Commands
+// with their own prefix use the shared address in preference to their own,
+// while their own network name or magic takes precedence over the shared
+// magic. See SharedNetworkMagic
+func LoadNodeConfig() (NodeConfig, error) {
+	var cfg NodeConfig
+	if err := envconfig.Process("cardano_node", &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// LoadLocalNodeConfig parses the CARDANO_NODE_* environment variables for the
+// Node-to-Client commands, using the default network's magic when none is
+// given and looking for the node socket in common locations when its path
+// isn't given
+func LoadLocalNodeConfig() (NodeConfig, error) {
+	cfg, err := LoadNodeConfig()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Magic, err = NetworkMagic("", cfg.Magic)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.SocketPath == "" {
+		cfg.SocketPath = FindSocketPath()
+	}
+	return cfg, CheckSocketPath(cfg.SocketPath)
+}
+
+// WarnIgnoredEnv warns when the fallback environment variable is set to a
+// different value than the shared one, since it is then ignored
+func WarnIgnoredEnv(shared string, fallback string) {
+	val, ok := os.LookupEnv(fallback)
+	if !ok || val == os.Getenv(shared) {
+		return
+	}
+	Warnf("%s is set, so %s is ignored", shared, fallback)
+}
+
+// SharedNetworkMagic returns the network magic for a Node-to-Node command,
+// using the shared CARDANO_NODE_MAGIC only when the command has no network
+// name or magic of its own. The shared magic is ignored, with a warning, when
+// connecting to the default mainnet relay, since it usually comes from a
+// workspace configured for another network
+func SharedNetworkMagic(
+	nodeCfg NodeConfig,
+	network string,
+	magic uint32,
+	addresses ...string,
+) uint32 {
+	if nodeCfg.Magic == 0 || network != "" || magic != 0 {
+		return magic
+	}
+	for _, address := range addresses {
+		if address == DefaultNodeAddress {
+			Warnf(
+				"CARDANO_NODE_MAGIC is ignored when connecting to the default mainnet relay %s; set CARDANO_NODE_ADDRESS to use it",
+				address,
+			)
+			return magic
+		}
+	}
+	return nodeCfg.Magic
+}
+
+// WarnDefaultNodeMagic warns when connecting to the default mainnet relay with
+// another network's magic, since the relay will refuse the handshake
+func WarnDefaultNodeMagic(address string, magic uint32) {
+	if address != DefaultNodeAddress ||
+		magic == ouroboros.NetworkMainnet.NetworkMagic {
+		return
+	}
+	Warnf(
+		"connecting to the default mainnet relay %s with network magic %d; set CARDANO_NODE_ADDRESS to a Node on that network",
+		address,
+		magic,
+	)
+}
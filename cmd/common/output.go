@@ -0,0 +1,83 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// When quiet is enabled, informational output is suppressed so that only the
+// actual results and errors are printed
+var quiet bool
+
+// When trace is enabled, gOuroboros debug logging of protocol messages is
+// written to stderr
+var trace bool
+
+// SetQuiet enables or disables quiet mode
+func SetQuiet(enabled bool) {
+	quiet = enabled
+}
+
+// SetTrace enables or disables protocol tracing
+func SetTrace(enabled bool) {
+	trace = enabled
+}
+
+// Logger returns the logger to pass to gOuroboros connections. When tracing is
+// enabled, this logs each protocol message sent and received to stderr
+func Logger() *slog.Logger {
+	if !trace {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return slog.New(
+		slog.NewTextHandler(
+			os.Stderr,
+			&slog.HandlerOptions{Level: slog.LevelDebug},
+		),
+	)
+}
+
+// Infoln prints informational output, such as headers, to stdout
+func Infoln(args ...any) {
+	if quiet {
+		return
+	}
+	text := strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	fmt.Println(colorize(os.Stdout, styleBold, text))
+}
+
+// Logf prints informational messages and warnings to stderr
+func Logf(format string, args ...any) {
+	if quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// Warnf prints a warning to stderr
+func Warnf(format string, args ...any) {
+	if quiet {
+		return
+	}
+	fmt.Fprintln(
+		os.Stderr,
+		colorize(os.Stderr, styleYellow, "WARNING: "+fmt.Sprintf(format, args...)),
+	)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultSocketPath is the node socket location used by Cardano Workspaces
+const DefaultSocketPath = "/ipc/node.socket"
+
+// SocketPathCandidates returns the list of common node socket locations, in
+// the order they should be checked
+func SocketPathCandidates() []string {
+	candidates := []string{DefaultSocketPath}
+	if cardanoHome := os.Getenv("CARDANO_HOME"); cardanoHome != "" {
+		candidates = append(
+			candidates,
+			filepath.Join(cardanoHome, "node.socket"),
+			filepath.Join(cardanoHome, "ipc", "node.socket"),
+		)
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		candidates = append(
+			candidates,
+			filepath.Join(homeDir, ".cardano", "node.socket"),
+		)
+	}
+	return candidates
+}
+
+// FindSocketPath returns the first node socket which exists out of the common
+// locations, logging which one was picked. If none exist, DefaultSocketPath
+// is returned
+func FindSocketPath() string {
+	for _, candidate := range SocketPathCandidates() {
+		if _, err := os.Stat(candidate); err == nil {
+			Logf("using node socket: %s", candidate)
+			return candidate
+		}
+	}
+	return DefaultSocketPath
+}
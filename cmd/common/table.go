@@ -0,0 +1,54 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table writes rows of values to stdout as aligned columns. The column
+// headers are informational output, so they are omitted in quiet mode
+type Table struct {
+	writer *tabwriter.Writer
+}
+
+// NewTable creates a table with the provided column headers
+func NewTable(headers ...string) *Table {
+	t := &Table{
+		writer: tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0),
+	}
+	if !quiet {
+		fmt.Fprintln(t.writer, strings.Join(headers, "\t"))
+	}
+	return t
+}
+
+// AddRow adds a row to the table, with one value per column
+func (t *Table) AddRow(values ...any) {
+	cells := make([]string, 0, len(values))
+	for _, val := range values {
+		cells = append(cells, fmt.Sprint(val))
+	}
+	fmt.Fprintln(t.writer, strings.Join(cells, "\t"))
+}
+
+// Flush writes the aligned table to stdout. It must be called after all rows
+// have been added
+func (t *Table) Flush() error {
+	return t.writer.Flush()
+}
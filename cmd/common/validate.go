@@ -0,0 +1,56 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// CheckSocketPath returns an error with guidance when the socket path for a
+// Node-to-Client connection looks like a TCP address instead
+func CheckSocketPath(path string) error {
+	if strings.ContainsAny(path, `/\`) {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(path); err == nil {
+		return fmt.Errorf(
+			"socket path %q looks like a TCP address: Node-to-Client examples such as this one connect to the Node's local socket, while Node-to-Node examples such as block-fetch and peer-sharing connect to a host:port address",
+			path,
+		)
+	}
+	return nil
+}
+
+// CheckNodeAddress returns an error with guidance when the address for a
+// Node-to-Node connection is not a host:port pair, such as a socket path
+func CheckNodeAddress(address string) error {
+	if strings.HasPrefix(address, "/") ||
+		strings.HasPrefix(address, `\\.\pipe\`) {
+		return fmt.Errorf(
+			"address %q looks like a socket path: Node-to-Node examples such as this one connect to a host:port address, while Node-to-Client examples such as chain-tip and tx-monitor connect to the Node's local socket",
+			address,
+		)
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf(
+			"invalid address %q, expected host:port: %w",
+			address,
+			err,
+		)
+	}
+	return nil
+}
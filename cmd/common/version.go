@@ -0,0 +1,69 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+)
+
+// These are populated at build time via -ldflags (see Makefile), and fall back
+// to the build info embedded by the Go toolchain
+var (
+	Version    string
+	CommitHash string
+)
+
+const gouroborosModulePath = "github.com/blinklabs-io/gouroboros"
+
+// VersionString returns the version of the running binary, the git commit it
+// was built from, and the version of gOuroboros it was built with
+func VersionString() string {
+	version := Version
+	commitHash := CommitHash
+	gouroborosVersion := "unknown"
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		if version == "" {
+			version = buildInfo.Main.Version
+		}
+		if commitHash == "" {
+			for _, setting := range buildInfo.Settings {
+				if setting.Key == "vcs.revision" {
+					commitHash = setting.Value
+				}
+			}
+		}
+		for _, dep := range buildInfo.Deps {
+			if dep.Path == gouroborosModulePath {
+				gouroborosVersion = dep.Version
+			}
+		}
+	}
+	if version == "" {
+		version = "unknown"
+	}
+	if commitHash == "" {
+		commitHash = "unknown"
+	}
+	return fmt.Sprintf(
+		"%s version %s (commit %s, gouroboros %s)",
+		filepath.Base(os.Args[0]),
+		version,
+		commitHash,
+		gouroborosVersion,
+	)
+}
@@ -15,77 +15,283 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"net"
+	"strconv"
+	"sync"
+	"time"
 
 	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
 	"github.com/kelseyhightower/envconfig"
 )
 
 // We parse environment variables using envconfig into this struct
 type Config struct {
-	Address      string
-	Network      string
-	NetworkMagic uint32 `split_words:"true"`
-	Peers        uint
+	Address           []string
+	Adjacency         bool
+	Depth             uint
+	Format            string
+	KeepaliveInterval time.Duration `split_words:"true"`
+	KeepaliveTimeout  time.Duration `split_words:"true"`
+	MaxPeers          uint          `split_words:"true"`
+	Network           string
+	NetworkMagic      uint32 `split_words:"true"`
+	Peers             uint
+	Workers           uint
 }
 
+// How long to wait for a Node to respond with its peers
+const peerRequestTimeout = 30 * time.Second
+
 // This code will be executed when run
 func main() {
 	// Set config defaults
 	var cfg = Config{
-		Address:      "backbone.cardano.iog.io:3001",
-		Network:      "mainnet",
+		Address:      []string{common.DefaultNodeAddress},
+		Adjacency:    false,
+		Depth:        1,
+		Format:       "text",
+		MaxPeers:     0,
+		Network:      "",
 		NetworkMagic: 0,
 		Peers:        10,
+		Workers:      10,
 	}
-	// Parse environment variables
+	// Parse command line flags
+	common.ParseFlags()
+	// Parse environment variables. The shared CARDANO_NODE_ADDRESS takes
+	// precedence over PEER_SHARING_ADDRESS, while a network name or magic given
+	// for this tool takes precedence over CARDANO_NODE_MAGIC
 	if err := envconfig.Process("peer_sharing", &cfg); err != nil {
-		panic(err)
+		common.Exit(common.ExitUsage, err)
+	}
+	nodeCfg, err := common.LoadNodeConfig()
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if nodeCfg.Address != "" {
+		common.WarnIgnoredEnv("CARDANO_NODE_ADDRESS", "PEER_SHARING_ADDRESS")
+		cfg.Address = []string{nodeCfg.Address}
+	}
+	for _, address := range cfg.Address {
+		if err := common.CheckNodeAddress(address); err != nil {
+			common.Exit(common.ExitUsage, err)
+		}
 	}
 	// Configure NetworkMagic
-	if cfg.NetworkMagic == 0 {
-		network, ok := ouroboros.NetworkByName(cfg.Network)
-		if !ok {
-			fmt.Printf("invalid network specified: %v\n", cfg.Network)
-			os.Exit(1)
+	cfg.NetworkMagic = common.SharedNetworkMagic(
+		nodeCfg,
+		cfg.Network,
+		cfg.NetworkMagic,
+		cfg.Address...,
+	)
+	networkMagic, err := common.NetworkMagic(cfg.Network, cfg.NetworkMagic)
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	cfg.NetworkMagic = networkMagic
+	for _, address := range cfg.Address {
+		common.WarnDefaultNodeMagic(address, cfg.NetworkMagic)
+	}
+	if cfg.Format != "text" && cfg.Format != "table" {
+		common.Exit(
+			common.ExitUsage,
+			fmt.Errorf(
+				"invalid format specified: %s (expected text or table)",
+				cfg.Format,
+			),
+		)
+	}
+	// Crawl the peer graph starting from the provided Node addresses. With
+	// the default depth of 1, this only queries the seed addresses
+	var peers []string
+	seenPeers := make(map[string]bool)
+	// Remember which Node first shared each peer, and at which depth
+	peerSources := make(map[string]string)
+	peerDepths := make(map[string]uint)
+	queried := make(map[string]bool)
+	adjacency := make(map[string][]string)
+	level := cfg.Address
+	for depth := uint(0); depth < cfg.Depth && len(level) > 0; depth++ {
+		results := getPeersConcurrent(cfg, level)
+		// Mark the whole level as queried first, so that a peer in this
+		// level shared by another isn't queried again at the next depth
+		for _, address := range level {
+			queried[address] = true
+		}
+		var nextLevel []string
+		var successCount, timeoutCount int
+		for idx, address := range level {
+			result := results[idx]
+			if result.err != nil {
+				if common.IsTimeout(result.err) {
+					timeoutCount++
+				}
+				common.Warnf(
+					"failed to get peers from %s: %s",
+					address,
+					result.err,
+				)
+				continue
+			}
+			successCount++
+			adjacency[address] = result.peers
+			for _, peer := range result.peers {
+				if seenPeers[peer] {
+					continue
+				}
+				if cfg.MaxPeers > 0 && uint(len(peers)) >= cfg.MaxPeers {
+					break
+				}
+				seenPeers[peer] = true
+				peerSources[peer] = address
+				peerDepths[peer] = depth + 1
+				peers = append(peers, peer)
+				if !queried[peer] {
+					nextLevel = append(nextLevel, peer)
+				}
+			}
+		}
+		if depth == 0 && successCount == 0 {
+			err := errors.New("failed to get peers from any address")
+			// Report a timeout when none of the seeds responded in time
+			if timeoutCount == len(level) {
+				err = fmt.Errorf(
+					"timed out getting peers from every address: %w",
+					context.DeadlineExceeded,
+				)
+			}
+			common.Exit(common.ExitConnection, err)
 		}
-		cfg.NetworkMagic = network.NetworkMagic
+		// Don't query the next depth once we have enough peers
+		if cfg.MaxPeers > 0 && uint(len(peers)) >= cfg.MaxPeers {
+			break
+		}
+		level = nextLevel
 	}
-	// Create error channel
-	errorChan := make(chan error)
-	// start error handler
-	go func() {
-		for {
-			err := <-errorChan
-			panic(err)
+
+	common.Infoln("Peers:")
+	common.Infoln()
+	if cfg.Format == "table" {
+		table := common.NewTable("HOST", "PORT", "DEPTH", "SOURCE")
+		for _, peer := range peers {
+			host, port, err := net.SplitHostPort(peer)
+			if err != nil {
+				host = peer
+			}
+			table.AddRow(host, port, peerDepths[peer], peerSources[peer])
 		}
-	}()
+		if err := table.Flush(); err != nil {
+			common.Exit(common.ExitError, err)
+		}
+	} else {
+		for _, peer := range peers {
+			fmt.Println(peer)
+		}
+	}
+	// Display who-knows-whom, if requested
+	if cfg.Adjacency {
+		jsonData, err := json.MarshalIndent(adjacency, "", "  ")
+		if err != nil {
+			common.Exit(common.ExitError, err)
+		}
+		common.Infoln()
+		common.Infoln("Adjacency:")
+		fmt.Println(string(jsonData))
+	}
+}
+
+// Result of getting peers from a single Node address
+type peersResult struct {
+	peers []string
+	err   error
+}
+
+// Get peers from each of the provided Node addresses, using a bounded pool of
+// workers. The results are returned in the same order as the addresses
+func getPeersConcurrent(cfg Config, addresses []string) []peersResult {
+	results := make([]peersResult, len(addresses))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := uint(0); i < max(cfg.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				peers, err := getPeers(cfg, addresses[idx])
+				results[idx] = peersResult{peers: peers, err: err}
+			}
+		}()
+	}
+	for idx := range addresses {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// Get requested number of peers from the Node at the provided address
+func getPeers(cfg Config, address string) ([]string, error) {
+	// Create error channel. We don't exit on async errors here, since a
+	// single unreachable peer shouldn't stop a crawl
+	errorChan := make(chan error, 10)
 	// Configure Ouroboros
 	o, err := ouroboros.NewConnection(
 		ouroboros.WithNetworkMagic(cfg.NetworkMagic),
 		ouroboros.WithErrorChan(errorChan),
+		ouroboros.WithLogger(common.Logger()),
 		ouroboros.WithNodeToNode(true),
 		ouroboros.WithKeepAlive(true),
+		ouroboros.WithKeepAliveConfig(
+			common.KeepAliveConfig(
+				cfg.KeepaliveInterval,
+				cfg.KeepaliveTimeout,
+			),
+		),
 		ouroboros.WithPeerSharing(true),
 		ouroboros.WithFullDuplex(true),
 	)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	// Connect to Node address
-	if err = o.Dial("tcp", cfg.Address); err != nil {
-		panic(err)
+	if err = common.DialNode(o, address); err != nil {
+		return nil, err
 	}
-	// Get requested number of peers from Node via NtN PeerSharing
-	peers, err := o.PeerSharing().Client.GetPeers(uint8(cfg.Peers))
-	if err != nil {
-		panic(err)
-	}
-
-	fmt.Println("Peers:")
-	fmt.Println()
-	for _, peer := range peers {
-		fmt.Printf("%s:%d\n", peer.IP.String(), peer.Port)
+	defer func() {
+		_ = o.Close()
+	}()
+	// Get peers from Node via NtN PeerSharing
+	resultChan := make(chan peersResult, 1)
+	go func() {
+		peerAddrs, err := o.PeerSharing().Client.GetPeers(uint8(cfg.Peers))
+		var peers []string
+		for _, peer := range peerAddrs {
+			// Use JoinHostPort so that IPv6 addresses are bracketed
+			peers = append(
+				peers,
+				net.JoinHostPort(
+					peer.IP.String(),
+					strconv.Itoa(int(peer.Port)),
+				),
+			)
+		}
+		resultChan <- peersResult{peers: peers, err: err}
+	}()
+	select {
+	case result := <-resultChan:
+		return result.peers, result.err
+	case err := <-errorChan:
+		return nil, err
+	case <-time.After(peerRequestTimeout):
+		return nil, fmt.Errorf(
+			"timed out waiting for peers: %w",
+			context.DeadlineExceeded,
+		)
 	}
 }
@@ -15,79 +15,151 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	models "github.com/blinklabs-io/cardano-models"
 	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
 	"github.com/blinklabs-io/gouroboros/ledger"
 	"github.com/fxamacker/cbor/v2"
 	"github.com/kelseyhightower/envconfig"
 )
 
-// We parse environment variables using envconfig into this struct
-type Config struct {
-	Magic      uint32
-	SocketPath string `split_words:"true"`
+// Options specific to this tool are parsed from TX_MONITOR_* environment
+// variables into this struct
+type MonitorConfig struct {
+	AlertThreshold float64 `split_words:"true"`
+	AssetsOnly     bool    `split_words:"true"`
+	FlatAssets     bool    `split_words:"true"`
+	Follow         bool
+	Interval       time.Duration
+	Json           bool
+	Since          string
+	SizesOnly      bool `split_words:"true"`
+	TxCount        bool `split_words:"true"`
 }
 
-// Create an Asset type using generics since the ledger code does not expose it
-type Asset[T ledger.MultiAssetTypeOutput | ledger.MultiAssetTypeMint] struct {
-	Name        string `json:"name"`
-	NameHex     string `json:"nameHex"`
-	PolicyId    string `json:"policyId"`
-	Fingerprint string `json:"fingerprint"`
-	Amount      T      `json:"amount"`
+// Mempool sizes, as returned by the LocalTxMonitor GetSizes query
+type MempoolSizes struct {
+	CapacityBytes uint32  `json:"capacityBytes"`
+	SizeBytes     uint32  `json:"sizeBytes"`
+	TxCount       uint32  `json:"txCount"`
+	FillPercent   float64 `json:"fillPercent"`
 }
 
 // This code will be executed when run
 func main() {
-	// Set config defaults
-	var cfg = Config{
-		Magic:      764824073,
-		SocketPath: "/ipc/node.socket",
-	}
-	// Parse environment variables
-	if err := envconfig.Process("cardano_node", &cfg); err != nil {
-		panic(err)
-	}
-	// Create error channel
-	errorChan := make(chan error)
-	// start error handler
-	go func() {
-		for {
-			err := <-errorChan
-			panic(err)
-		}
-	}()
-	// Configure Ouroboros
-	o, err := ouroboros.NewConnection(
-		ouroboros.WithNetworkMagic(uint32(cfg.Magic)),
-		ouroboros.WithErrorChan(errorChan),
-		ouroboros.WithNodeToNode(false),
-	)
+	// Parse command line flags
+	common.ParseFlags()
+	// Parse the shared CARDANO_NODE_* environment variables
+	cfg, err := common.LoadLocalNodeConfig()
 	if err != nil {
-		panic(err)
+		common.Exit(common.ExitUsage, err)
+	}
+	var monitorCfg = MonitorConfig{
+		Interval: 5 * time.Second,
+	}
+	if err := envconfig.Process("tx_monitor", &monitorCfg); err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	// Tx hashes are compared in their lower-case hex form
+	monitorCfg.Since = strings.ToLower(monitorCfg.Since)
+	// Only the mempool sizes have a JSON form, so JSON implies sizes only
+	if monitorCfg.Json {
+		if monitorCfg.Follow {
+			common.Exit(
+				common.ExitUsage,
+				errors.New("follow mode does not support JSON output"),
+			)
+		}
+		monitorCfg.SizesOnly = true
+	}
+	if monitorCfg.Follow && monitorCfg.Interval <= 0 {
+		common.Exit(
+			common.ExitUsage,
+			errors.New("interval must be greater than zero"),
+		)
 	}
 	// Connect to Node socket
-	if err = o.Dial("unix", cfg.SocketPath); err != nil {
-		panic(err)
+	o, errorChan, err := common.ConnectLocal(cfg)
+	if err != nil {
+		common.Exit(common.ExitProtocol, err)
 	}
+	common.ExitOnAsyncError(errorChan)
 	// Get mempool sizes from Node via LocalTxMonitor Ouroboros mini-protocol
 	capacity, size, numberOfTxs, err := o.LocalTxMonitor().Client.GetSizes()
 	if err != nil {
-		panic(err)
+		common.Exit(common.ExitProtocol, err)
+	}
+	// Output only the number of transactions, for use in gauges
+	if monitorCfg.TxCount {
+		fmt.Println(numberOfTxs)
+		return
+	}
+	var fillPercent float64
+	if capacity > 0 {
+		fillPercent = float64(size) / float64(capacity) * 100
+	}
+	if monitorCfg.Json {
+		sizes := MempoolSizes{
+			CapacityBytes: capacity,
+			SizeBytes:     size,
+			TxCount:       numberOfTxs,
+			FillPercent:   fillPercent,
+		}
+		jsonData, err := json.Marshal(sizes)
+		if err != nil {
+			common.Exit(common.ExitError, err)
+		}
+		fmt.Println(string(jsonData))
+	} else {
+		fmt.Printf(
+			"Mempool size (bytes): %-10d Mempool capacity (bytes): %-10d Transactions: %-10d Fill: %.2f%%\n",
+			size,
+			capacity,
+			numberOfTxs,
+			fillPercent,
+		)
+		common.Infoln()
+	}
+	// Warn when the mempool is nearly full, and exit with a distinct code
+	// once we're done so that cron jobs can alert on it
+	alert := monitorCfg.AlertThreshold > 0 &&
+		fillPercent >= monitorCfg.AlertThreshold
+	if alert {
+		common.Warnf(
+			"mempool is %.2f%% full, which is above the alert threshold of %.2f%%",
+			fillPercent,
+			monitorCfg.AlertThreshold,
+		)
+	}
+	// Draining the mempool is expensive, so stop here if we only want sizes
+	if monitorCfg.SizesOnly {
+		if alert {
+			os.Exit(common.ExitAlert)
+		}
+		return
 	}
-	fmt.Printf(
-		"Mempool size (bytes): %-10d Mempool capacity (bytes): %-10d Transactions: %-10d\n",
-		size,
-		capacity,
-		numberOfTxs,
-	)
-	fmt.Println()
 
 	// Get all transactions
-	fmt.Println("Transactions:")
+	common.Infoln("Transactions:")
+
+	// Keep running totals over all drained transactions for the aggregate
+	// stats at the end
+	var totalFees uint64
+	var totalTxBytes int
+	// When a starting tx hash is given, skip transactions until we've seen it
+	sinceFound := monitorCfg.Since == ""
+	// Remember which transactions we've displayed, for follow mode
+	seen := make(map[string]bool)
 
 	// The Ouroboros LocalTxMonitor mini-protocol allows fetching all of the
 	// contents of the Node mempool. However, you have to loop and fetch
@@ -96,7 +168,7 @@ func main() {
 		// Get raw Tx bytes from Node via LocalTxMonitor
 		txRawBytes, err := o.LocalTxMonitor().Client.NextTx()
 		if err != nil {
-			panic(err)
+			common.Exit(common.ExitProtocol, err)
 		}
 		// Break loop if empty
 		if txRawBytes == nil {
@@ -104,119 +176,225 @@ func main() {
 		}
 		// Get Tx size of raw Tx bytes
 		size := len(txRawBytes)
-		// Determine transaction type (era) from raw Tx bytes
-		txType, err := ledger.DetermineTransactionType(txRawBytes)
-		if err != nil {
-			panic(err)
+		tx := decodeTx(txRawBytes)
+		seen[tx.Hash()] = true
+		// The totals cover the whole mempool, like the fill percentage
+		totalFees += tx.Fee()
+		totalTxBytes += size
+		if !sinceFound {
+			if tx.Hash() == monitorCfg.Since {
+				sinceFound = true
+			}
+			continue
 		}
-		// Get ledger.Transaction from raw Tx bytes
-		tx, err := ledger.NewTransactionFromCbor(txType, txRawBytes)
-		if err != nil {
-			panic(err)
+		printTx(tx, size, monitorCfg)
+	}
+
+	if !sinceFound {
+		common.Warnf(
+			"transaction %s was not found in the mempool",
+			monitorCfg.Since,
+		)
+	}
+	// Display aggregate stats for the mempool contents
+	fmt.Println("Summary:")
+	fmt.Printf(
+		" %-20s %d\n",
+		"Total fees:",
+		totalFees,
+	)
+	var feePerByte float64
+	if totalTxBytes > 0 {
+		feePerByte = float64(totalFees) / float64(totalTxBytes)
+	}
+	fmt.Printf(
+		" %-20s %.2f\n",
+		"Avg fee/byte:",
+		feePerByte,
+	)
+	fmt.Printf(
+		" %-20s %.2f%%\n",
+		"Fill:",
+		fillPercent,
+	)
+	// Keep printing new transactions as they arrive, if requested
+	if monitorCfg.Follow {
+		followMempool(o, monitorCfg, seen)
+	}
+	if alert {
+		os.Exit(common.ExitAlert)
+	}
+}
+
+// Poll the mempool until interrupted, displaying only transactions which
+// were not present in the previous snapshot
+func followMempool(
+	o *ouroboros.Connection,
+	monitorCfg MonitorConfig,
+	seen map[string]bool,
+) {
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+	// Poll in the background, since the LocalTxMonitor calls can block for
+	// a long time waiting on the node, and we want to stop promptly when
+	// interrupted
+	go pollMempool(ctx, o, monitorCfg, seen)
+	<-ctx.Done()
+}
+
+// Repeatedly acquire the mempool, displaying new transactions, until the
+// context is cancelled
+func pollMempool(
+	ctx context.Context,
+	o *ouroboros.Connection,
+	monitorCfg MonitorConfig,
+	seen map[string]bool,
+) {
+	ticker := time.NewTicker(monitorCfg.Interval)
+	defer ticker.Stop()
+	client := o.LocalTxMonitor().Client
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
 		}
-		fmt.Println(" ---")
-		// Print Tx size and Tx Hash (of Tx Body)
+		// Release the previous snapshot and acquire the current mempool
+		if err := client.Release(); err != nil {
+			common.Exit(common.ExitProtocol, err)
+		}
+		if err := client.Acquire(); err != nil {
+			common.Exit(common.ExitProtocol, err)
+		}
+		current := make(map[string]bool)
+		for {
+			txRawBytes, err := client.NextTx()
+			if err != nil {
+				common.Exit(common.ExitProtocol, err)
+			}
+			if txRawBytes == nil {
+				break
+			}
+			tx := decodeTx(txRawBytes)
+			current[tx.Hash()] = true
+			if !seen[tx.Hash()] {
+				printTx(tx, len(txRawBytes), monitorCfg)
+			}
+		}
+		// Only track what is still in the mempool, so the set doesn't grow
+		seen = current
+	}
+}
+
+// Decode raw transaction bytes from the mempool
+func decodeTx(txRawBytes []byte) ledger.Transaction {
+	// Determine transaction type (era) from raw Tx bytes
+	txType, err := ledger.DetermineTransactionType(txRawBytes)
+	if err != nil {
+		common.Exit(common.ExitError, err)
+	}
+	// Get ledger.Transaction from raw Tx bytes
+	tx, err := ledger.NewTransactionFromCbor(txType, txRawBytes)
+	if err != nil {
+		common.Exit(common.ExitError, err)
+	}
+	return tx
+}
+
+// Display the details of a single mempool transaction
+func printTx(tx ledger.Transaction, size int, monitorCfg MonitorConfig) {
+	fmt.Println(" ---")
+	// Print Tx size, fee, and Tx Hash (of Tx Body)
+	fmt.Printf(
+		" %-20s %d\n",
+		"Size:",
+		size,
+	)
+	fmt.Printf(
+		" %-20s %d\n",
+		"Fee:",
+		tx.Fee(),
+	)
+	fmt.Printf(
+		" %-20s %s\n",
+		"TxHash:",
+		tx.Hash(),
+	)
+	// Print number of inputs
+	fmt.Printf(
+		" %-20s %d\n",
+		"Inputs:",
+		len(tx.Inputs()),
+	)
+	// Loop through transaction inputs and print ID#Index
+	for i, input := range tx.Inputs() {
 		fmt.Printf(
-			" %-20s %d\n",
-			"Size:",
-			size,
+			" %-20s %s\n",
+			fmt.Sprintf("Input[%d]:", i),
+			fmt.Sprintf("%s#%d", input.Id().String(), input.Index()),
 		)
+	}
+	// Print number of outputs
+	fmt.Printf(
+		" %-20s %d\n",
+		"Outputs:",
+		len(tx.Outputs()),
+	)
+	// Loop through transaction outputs
+	for o, output := range tx.Outputs() {
+		// Skip pure-ADA outputs, if requested
+		if monitorCfg.AssetsOnly && !common.HasAssets(output) {
+			continue
+		}
 		fmt.Printf(
 			" %-20s %s\n",
-			"TxHash:",
-			tx.Hash(),
+			fmt.Sprintf("Output[%d]:", o),
+			fmt.Sprintf("Address: %s", output.Address().String()),
 		)
-		// Print number of inputs
 		fmt.Printf(
-			" %-20s %d\n",
-			"Inputs:",
-			len(tx.Inputs()),
+			" %-20s %s\n",
+			fmt.Sprintf("Output[%d]:", o),
+			fmt.Sprintf("Amount: %d", output.Amount()),
 		)
-		// Loop through transaction inputs and print ID#Index
-		for i, input := range tx.Inputs() {
-			fmt.Printf(
-				" %-20s %s\n",
-				fmt.Sprintf("Input[%d]:", i),
-				fmt.Sprintf("%s#%d", input.Id().String(), input.Index()),
-			)
+		if output.Assets() == nil {
+			continue
 		}
-		// Print number of outputs
-		fmt.Printf(
-			" %-20s %d\n",
-			"Outputs:",
-			len(tx.Outputs()),
+		common.PrintAssets(
+			output.Assets(),
+			fmt.Sprintf(" %-20s ", fmt.Sprintf("Output[%d]:", o)),
+			monitorCfg.FlatAssets,
 		)
-		// Loop through transaction outputs
-		for o, output := range tx.Outputs() {
-			fmt.Printf(
-				" %-20s %s\n",
-				fmt.Sprintf("Output[%d]:", o),
-				fmt.Sprintf("Address: %s", output.Address().String()),
-			)
-			fmt.Printf(
-				" %-20s %s\n",
-				fmt.Sprintf("Output[%d]:", o),
-				fmt.Sprintf("Amount: %d", output.Amount()),
-			)
-			if output.Assets() == nil {
-				continue
-			}
-			// We do not have a direct way to go from the Assets()
-			// output from gOuroboros to an easily iterable list
-			// of assets ([]Asset), so we use JSON parsing as an
-			// intermediary step.
-
-			// Marshal to JSON bytes from ledger.MultiAsset
-			j, _ := output.Assets().MarshalJSON()
-			var assets []Asset[ledger.MultiAssetTypeOutput]
-			// Unmarshal JSON bytes to list of Assets
-			err := json.Unmarshal(j, &assets)
-			if err != nil {
-				panic(err)
-			}
-			// Loop through each asset and display
-			for a, asset := range assets {
+	}
+	// Check if transaction has any metadata
+	if tx.Metadata() != nil {
+		// Get CBOR bytes of metadata
+		mdCbor := tx.Metadata().Cbor()
+
+		// Check if the CBOR bytes matches one of our known
+		// metadata types exposed in our models. Currently,
+		// only CIP-20 messages are supported.
+
+		// Check if the CBOR bytes matches CIP-20
+		var msgMetadata models.Cip20Metadata
+		err := cbor.Unmarshal(mdCbor, &msgMetadata)
+		if err != nil {
+			// Do nothing on error
+			return
+		}
+		// Display message if found
+		if msgMetadata.Num674.Msg != nil {
+			for m, msg := range msgMetadata.Num674.Msg {
 				fmt.Printf(
 					" %-20s %s\n",
-					fmt.Sprintf("Output[%d]:", o),
-					fmt.Sprintf(
-						"Asset[%d]: Policy: %s, Name: %s, Amount: %d",
-						a,
-						asset.PolicyId,
-						asset.Name,
-						asset.Amount,
-					),
+					fmt.Sprintf("Metadata[Msg][%d]:", m),
+					msg,
 				)
 			}
-
-		}
-		// Check if transaction has any metadata
-		if tx.Metadata() != nil {
-			// Get CBOR bytes of metadata
-			mdCbor := tx.Metadata().Cbor()
-
-			// Check if the CBOR bytes matches one of our known
-			// metadata types exposed in our models. Currently,
-			// only CIP-20 messages are supported.
-
-			// Check if the CBOR bytes matches CIP-20
-			var msgMetadata models.Cip20Metadata
-			err := cbor.Unmarshal(mdCbor, &msgMetadata)
-			if err != nil {
-				// Do nothing on error
-				continue
-			}
-			// Display message if found
-			if msgMetadata.Num674.Msg != nil {
-				for m, msg := range msgMetadata.Num674.Msg {
-					fmt.Printf(
-						" %-20s %s\n",
-						fmt.Sprintf("Metadata[Msg][%d]:", m),
-						msg,
-					)
-				}
-			}
 		}
-		fmt.Println()
 	}
+	fmt.Println()
 }
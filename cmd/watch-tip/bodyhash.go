@@ -0,0 +1,73 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// computeBodyHash recomputes the block body hash from the block CBOR. Each
+// body segment following the header (transaction bodies, witness sets,
+// metadata, and from Alonzo the invalid transactions) is hashed, and then the
+// concatenation of those hashes is hashed
+func computeBodyHash(blockCbor []byte) (lcommon.Blake2b256, error) {
+	var segments []cbor.RawMessage
+	if err := cbor.Unmarshal(blockCbor, &segments); err != nil {
+		return lcommon.Blake2b256{}, err
+	}
+	if len(segments) < 4 {
+		return lcommon.Blake2b256{}, errors.New("block has too few segments")
+	}
+	var segmentHashes []byte
+	for _, segment := range segments[1:] {
+		segmentHash := lcommon.Blake2b256Hash(segment)
+		segmentHashes = append(segmentHashes, segmentHash.Bytes()...)
+	}
+	return lcommon.Blake2b256Hash(segmentHashes), nil
+}
+
+// checkBodyHash warns when the body hash recomputed from the block doesn't
+// match the one committed to in its header
+func checkBodyHash(block ledger.Block) {
+	header, ok := common.BlockHeaderInfo(block)
+	if !ok {
+		// Byron headers use a different body proof
+		return
+	}
+	declared := header.BlockBodyHash
+	computed, err := computeBodyHash(block.Cbor())
+	if err != nil {
+		common.Warnf(
+			"failed to compute body hash for block %s: %s",
+			block.Hash(),
+			err,
+		)
+		return
+	}
+	if computed != declared {
+		common.Warnf(
+			"body hash mismatch: block %s at slot %d declares %s, computed %s",
+			block.Hash(),
+			block.SlotNumber(),
+			declared,
+			computed,
+		)
+	}
+}
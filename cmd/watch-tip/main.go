@@ -0,0 +1,226 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros-starter-kit/cmd/common"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/protocol/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Options specific to this tool are parsed from WATCH_TIP_* environment
+// variables into this struct
+type WatchConfig struct {
+	BodyHashCheck bool `split_words:"true"`
+	Json          bool
+	PrevHashCheck bool `split_words:"true"`
+}
+
+// TipEvent describes a new block at the tip of the chain
+type TipEvent struct {
+	Slot        uint64 `json:"slot"`
+	Hash        string `json:"hash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Era         string `json:"era"`
+}
+
+var watchCfg WatchConfig
+
+// The point we started following from, which the node sends us a rollback to
+// before the first new block
+var startPoint ocommon.Point
+var startPointOnce sync.Once
+
+// How many recent blocks to remember for detecting slot battles
+const recentBlockCount = 100
+
+// A block we've seen recently, for comparing against the block which replaces
+// it after a rollback
+type recentBlock struct {
+	Slot   uint64
+	Hash   string
+	Issuer string
+}
+
+// Recent blocks, by block number
+var recentBlocks = make(map[uint64]recentBlock)
+
+// The hash of the last block we saw, or rolled back to, for checking that each
+// new block follows on from it
+var lastHash string
+
+// This code will be executed when run
+func main() {
+	// Parse command line flags
+	common.ParseFlags()
+	// Parse the shared CARDANO_NODE_* environment variables
+	cfg, err := common.LoadLocalNodeConfig()
+	if err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	if err := envconfig.Process("watch_tip", &watchCfg); err != nil {
+		common.Exit(common.ExitUsage, err)
+	}
+	// Connect to Node socket
+	o, errorChan, err := common.ConnectLocal(
+		cfg,
+		ouroboros.WithChainSyncConfig(
+			chainsync.NewConfig(
+				chainsync.WithRollForwardFunc(rollForwardHandler),
+				chainsync.WithRollBackwardFunc(rollBackwardHandler),
+			),
+		),
+	)
+	if err != nil {
+		common.Exit(common.ExitProtocol, err)
+	}
+	common.ExitOnAsyncError(errorChan)
+	// Get current tip from Node via NtC ChainSync, and start following from it
+	tip, err := o.ChainSync().Client.GetCurrentTip()
+	if err != nil {
+		common.Exit(common.ExitProtocol, err)
+	}
+	startPoint = tip.Point
+	lastHash = hex.EncodeToString(tip.Point.Hash)
+	// Keep stdout as pure JSON lines in JSON mode
+	header := fmt.Sprintf(
+		"Following tip from slot %d, hash %x",
+		tip.Point.Slot,
+		tip.Point.Hash,
+	)
+	if watchCfg.Json {
+		common.Logf("%s", header)
+	} else {
+		common.Infoln(header)
+	}
+	if err := o.ChainSync().Client.Sync([]ocommon.Point{tip.Point}); err != nil {
+		common.Exit(common.ExitProtocol, err)
+	}
+	// Wait forever, printing each new tip as it arrives
+	select {}
+}
+
+// Print each new block at the tip as it arrives
+func rollForwardHandler(
+	ctx chainsync.CallbackContext,
+	blockType uint,
+	blockData interface{},
+	tip chainsync.Tip,
+) error {
+	block, ok := blockData.(ledger.Block)
+	if !ok {
+		return fmt.Errorf("unexpected block data type: %T", blockData)
+	}
+	// Check that the block follows on from the last one we saw
+	if watchCfg.PrevHashCheck {
+		if lastHash != "" && block.PrevHash() != lastHash {
+			common.Warnf(
+				"chain discontinuity: block %s at slot %d has previous hash %s, expected %s",
+				block.Hash(),
+				block.SlotNumber(),
+				block.PrevHash(),
+				lastHash,
+			)
+		}
+		lastHash = block.Hash()
+	}
+	// Check that the block body matches the hash committed to in its header
+	if watchCfg.BodyHashCheck {
+		checkBodyHash(block)
+	}
+	checkSlotBattle(block)
+	event := TipEvent{
+		Slot:        block.SlotNumber(),
+		Hash:        block.Hash(),
+		BlockNumber: block.BlockNumber(),
+		Era:         block.Era().Name,
+	}
+	if watchCfg.Json {
+		jsonData, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+	fmt.Printf(
+		"Tip: era = %s, slot = %d, block_no = %d, id = %s\n",
+		event.Era,
+		event.Slot,
+		event.BlockNumber,
+		event.Hash,
+	)
+	return nil
+}
+
+// Print rollbacks, other than the initial one to our starting point
+func rollBackwardHandler(
+	ctx chainsync.CallbackContext,
+	point ocommon.Point,
+	tip chainsync.Tip,
+) error {
+	// The next block should follow on from the rollback point
+	lastHash = hex.EncodeToString(point.Hash)
+	isStart := false
+	startPointOnce.Do(func() {
+		isStart = point.Slot == startPoint.Slot
+	})
+	if isStart {
+		return nil
+	}
+	common.Logf(
+		"Rollback: slot = %d, hash = %x",
+		point.Slot,
+		point.Hash,
+	)
+	return nil
+}
+
+// Log when a block replaces a different block we saw at the same block
+// number, which after a rollback indicates a probable slot battle
+func checkSlotBattle(block ledger.Block) {
+	current := recentBlock{
+		Slot:   block.SlotNumber(),
+		Hash:   block.Hash(),
+		Issuer: block.IssuerVkey().PoolId(),
+	}
+	blockNumber := block.BlockNumber()
+	if previous, ok := recentBlocks[blockNumber]; ok &&
+		previous.Hash != current.Hash {
+		common.Logf(
+			"Slot battle: block_no = %d, replaced %s (slot %d, issuer %s) with %s (slot %d, issuer %s)",
+			blockNumber,
+			previous.Hash,
+			previous.Slot,
+			previous.Issuer,
+			current.Hash,
+			current.Slot,
+			current.Issuer,
+		)
+	}
+	recentBlocks[blockNumber] = current
+	// Forget older blocks, which are too deep to be rolled back in practice
+	if blockNumber > recentBlockCount {
+		delete(recentBlocks, blockNumber-recentBlockCount)
+	}
+}
@@ -3,10 +3,12 @@ module github.com/blinklabs-io/gouroboros-starter-kit
 go 1.22.11
 
 require (
+	github.com/Microsoft/go-winio v0.6.2
 	github.com/blinklabs-io/cardano-models v0.4.0
 	github.com/blinklabs-io/gouroboros v0.108.2
 	github.com/fxamacker/cbor/v2 v2.7.0
 	github.com/kelseyhightower/envconfig v1.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (